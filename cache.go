@@ -0,0 +1,229 @@
+package gocherwell
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/itsscb/gocherwell/internal/atomicfile"
+)
+
+// Keys of the Entries of a MetadataCache.
+const (
+	summariesKey = "summaries:"
+	summaryKey   = "summary:"
+	schemaKey    = "schema:"
+	templateKey  = "template:"
+)
+
+// MetadataCache caches the Summaries, Schemas and Templates of BusinessObjects, so they are
+// not downloaded again for every lookup, search or link. It is safe for concurrent use and
+// may be shared by several Clients of the same Cherwell Server.
+type MetadataCache struct {
+	// TTL is the time an Entry stays valid. Zero means Entries never expire.
+	TTL time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+// cacheEntry is a cached Response of the Cherwell API. It is kept as JSON, so every
+// caller receives its own copy.
+type cacheEntry struct {
+	BusObID string          `json:"busObId,omitempty"`
+	Stored  time.Time       `json:"stored"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// NewMetadataCache returns a Pointer to an empty MetadataCache with the given TTL.
+func NewMetadataCache(ttl time.Duration) *MetadataCache {
+	return &MetadataCache{TTL: ttl, entries: make(map[string]cacheEntry)}
+}
+
+// WithMetadataCache sets the MetadataCache of the Client. By default nothing is cached.
+func WithMetadataCache(c *MetadataCache) Option {
+	return func(o *options) {
+		o.metadataCache = c
+	}
+}
+
+// Invalidate removes the Schemas and Templates of the BusinessObjects with the given BusObIDs
+// and all Summaries. Without BusObIDs all Entries are removed.
+func (c *MetadataCache) Invalidate(busObIDs ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(busObIDs) == 0 {
+		c.entries = make(map[string]cacheEntry)
+		return
+	}
+	for k, e := range c.entries {
+		if e.BusObID == "" {
+			delete(c.entries, k)
+			continue
+		}
+		for _, id := range busObIDs {
+			if e.BusObID == id {
+				delete(c.entries, k)
+			}
+		}
+	}
+}
+
+// Len returns the Number of valid Entries.
+func (c *MetadataCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := 0
+	for _, e := range c.entries {
+		if c.valid(e) {
+			n++
+		}
+	}
+	return n
+}
+
+// Save writes all valid Entries to a file readable only by the current user, e.g. to keep them between runs of a CLI tool.
+func (c *MetadataCache) Save(path string) error {
+	c.mu.Lock()
+	entries := make(map[string]cacheEntry)
+	for k, e := range c.entries {
+		if c.valid(e) {
+			entries[k] = e
+		}
+	}
+	c.mu.Unlock()
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return atomicfile.WriteFile(path, data, 0o600)
+}
+
+// Load reads the Entries written by Save and adds the valid ones to the MetadataCache.
+// A missing file is not an error.
+func (c *MetadataCache) Load(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	entries := make(map[string]cacheEntry)
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("failed to load MetadataCache: %w (Path: %v)", err, path)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]cacheEntry)
+	}
+	for k, e := range entries {
+		if c.valid(e) {
+			c.entries[k] = e
+		}
+	}
+	return nil
+}
+
+// get unmarshals the valid Entry with the given Key to output and reports whether it was found
+func (c *MetadataCache) get(key string, output interface{}) bool {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	c.mu.Unlock()
+	if !ok || !c.valid(e) {
+		return false
+	}
+	return json.Unmarshal(e.Data, output) == nil
+}
+
+// set stores the given Value as Entry with the given Key
+func (c *MetadataCache) set(key, busObID string, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]cacheEntry)
+	}
+	c.entries[key] = cacheEntry{BusObID: busObID, Stored: time.Now(), Data: data}
+}
+
+// valid reports whether the Entry has not expired
+func (c *MetadataCache) valid(e cacheEntry) bool {
+	return c.TTL <= 0 || time.Since(e.Stored) < c.TTL
+}
+
+// MetadataCache returns the MetadataCache of the Client or nil if none is set.
+func (cl *Client) MetadataCache() *MetadataCache {
+	return cl.cache
+}
+
+// InvalidateMetadata removes the cached Metadata of the BusinessObjects with the given BusObIDs or all if none are given.
+func (cl *Client) InvalidateMetadata(busObIDs ...string) {
+	if cl.cache != nil {
+		cl.cache.Invalidate(busObIDs...)
+	}
+}
+
+// PreloadMetadata loads the Summaries and the Schemas and Templates of the BusinessObjects with the
+// given DisplayNames into the MetadataCache. Without DisplayNames all BusinessObjects are loaded,
+// which takes two calls per BusinessObject.
+func (cl *Client) PreloadMetadata(ctx context.Context, displayNames ...string) error {
+	if cl.cache == nil {
+		return nil
+	}
+	summaries, err := cl.getBusinessObjectSummaries(ctx, BusinessObjectTypeAll)
+	if err != nil {
+		return err
+	}
+	objects := []BusinessObject{}
+	if len(displayNames) == 0 {
+		for _, b := range summaries {
+			objects = append(objects, b)
+			objects = append(objects, b.GroupSummaries...)
+		}
+	}
+	for _, name := range displayNames {
+		bo, err := cl.GetBusinessObjectByDisplayName(ctx, name)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, *bo)
+	}
+	for i := range objects {
+		if _, err := objects[i].GetBusinessObjectSchema(ctx, cl); err != nil {
+			return err
+		}
+		if _, err := objects[i].getBusinessObjectTemplate(ctx, cl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cached returns the cached Value with the given Key in output or loads it with load and caches it
+func (cl *Client) cached(key, busObID string, output interface{}, load func() error) error {
+	if cl.cache != nil && cl.cache.get(key, output) {
+		cl.log().Debug("metadata cache hit", "key", key)
+		return nil
+	}
+	if err := load(); err != nil {
+		return err
+	}
+	if cl.cache != nil {
+		cl.cache.set(key, busObID, output)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package gocherwell_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/itsscb/gocherwell"
+)
+
+func TestMetadataCache(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       func(ctx context.Context, cl *gocherwell.Client) error
+		endpoint string
+		requests int
+		err      error
+	}{
+		{"schema is cached", func(ctx context.Context, cl *gocherwell.Client) error {
+			_, err := cl.GetBusinessObjectSchema(ctx, &gocherwell.BusinessObject{BusObID: "BO1"})
+			return err
+		}, "getbusinessobjectschema", 1, nil},
+		{"summary is cached", func(ctx context.Context, cl *gocherwell.Client) error {
+			_, err := cl.GetBusinessObjectByName(ctx, "ConfigComputer")
+			return err
+		}, "getbusinessobjectsummary/busobname", 1, nil},
+		{"summaries are cached", func(ctx context.Context, cl *gocherwell.Client) error {
+			_, err := cl.GetBusinessObjectByDisplayName(ctx, "Computer")
+			return err
+		}, "getbusinessobjectsummaries", 1, nil},
+		{"template is cached", func(ctx context.Context, cl *gocherwell.Client) error {
+			_, err := cl.NewBusinessObjectRecord(ctx, &gocherwell.BusinessObject{BusObID: "BO1"}, []gocherwell.Field{{Name: "AssetName", Value: "NB100"}})
+			return err
+		}, "getbusinessobjecttemplate", 1, nil},
+		{"missing summary is not cached", func(ctx context.Context, cl *gocherwell.Client) error {
+			_, err := cl.GetBusinessObjectByName(ctx, "Missing")
+			return err
+		}, "getbusinessobjectsummary/busobname", 2, gocherwell.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer()
+			defer srv.Close()
+			ctx := context.Background()
+			cache := gocherwell.NewMetadataCache(0)
+			cl := srv.NewClient(gocherwell.WithMetadataCache(cache))
+			for i := 0; i < 2; i++ {
+				if err := tt.op(ctx, cl); !errors.Is(err, tt.err) {
+					t.Fatalf("call %v error = %v, want %v", i+1, err, tt.err)
+				}
+			}
+			if got := countRequests(srv, tt.endpoint); got != tt.requests {
+				t.Errorf("Requests = %v, want %v", got, tt.requests)
+			}
+		})
+	}
+}
+
+func TestMetadataCacheInvalidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		ttl        time.Duration
+		invalidate func(cl *gocherwell.Client)
+		requests   int
+	}{
+		{"valid entry", 0, func(cl *gocherwell.Client) {}, 1},
+		{"other business object", 0, func(cl *gocherwell.Client) { cl.InvalidateMetadata("BO2") }, 1},
+		{"business object", 0, func(cl *gocherwell.Client) { cl.InvalidateMetadata("BO1") }, 2},
+		{"all", 0, func(cl *gocherwell.Client) { cl.InvalidateMetadata() }, 2},
+		{"expired", time.Millisecond, func(cl *gocherwell.Client) { time.Sleep(5 * time.Millisecond) }, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer()
+			defer srv.Close()
+			ctx := context.Background()
+			cl := srv.NewClient(gocherwell.WithMetadataCache(gocherwell.NewMetadataCache(tt.ttl)))
+			bo := &gocherwell.BusinessObject{BusObID: "BO1"}
+			if _, err := bo.GetBusinessObjectSchema(ctx, cl); err != nil {
+				t.Fatal(err)
+			}
+			tt.invalidate(cl)
+			if _, err := bo.GetBusinessObjectSchema(ctx, cl); err != nil {
+				t.Fatal(err)
+			}
+			if got := countRequests(srv, "getbusinessobjectschema"); got != tt.requests {
+				t.Errorf("Requests = %v, want %v", got, tt.requests)
+			}
+		})
+	}
+}
+
+func TestMetadataCacheSaveLoad(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "gocherwell")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cache", "metadata.json")
+
+	cache := gocherwell.NewMetadataCache(time.Hour)
+	cl := srv.NewClient(gocherwell.WithMetadataCache(cache))
+	if err := cl.PreloadMetadata(ctx, "Computer"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := gocherwell.NewMetadataCache(time.Hour)
+	if err := loaded.Load(path); err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Len() != cache.Len() {
+		t.Errorf("Len() = %v, want %v", loaded.Len(), cache.Len())
+	}
+	before := len(srv.Requests())
+	cl = srv.NewClient(gocherwell.WithMetadataCache(loaded))
+	if _, err := cl.NewBusinessObjectRecord(ctx, &gocherwell.BusinessObject{BusObID: "BO1"}, []gocherwell.Field{{Name: "AssetName", Value: "NB100"}}); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range srv.Requests()[before:] {
+		if r.Method == "GET" || !containsAny(r.Path, "savebusinessobject", "/token") {
+			t.Errorf("unexpected Request %v %v, want Metadata from the loaded MetadataCache", r.Method, r.Path)
+		}
+	}
+
+	if err := gocherwell.NewMetadataCache(0).Load(filepath.Join(dir, "missing.json")); err != nil {
+		t.Errorf("Load() of missing file error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,349 @@
+// cassette records the HTTP-Interactions of a gocherwell.Client with a Cherwell Server to a file
+// and replays them in tests. Passwords and Tokens are scrubbed before anything is written.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/itsscb/gocherwell"
+	"github.com/itsscb/gocherwell/internal/atomicfile"
+)
+
+// ErrNoInteraction is returned by a replaying Recorder if no recorded Interaction matches a Request.
+var ErrNoInteraction = errors.New("cassette: no matching interaction")
+
+// Mode selects whether a Recorder records or replays Interactions.
+type Mode int
+
+const (
+	// ModeReplay serves all Requests from the Cassette and never sends them.
+	ModeReplay Mode = iota
+	// ModeRecord sends all Requests and appends the Interactions to the Cassette.
+	ModeRecord
+	// ModeAuto replays if the Cassette exists and records otherwise.
+	ModeAuto
+)
+
+// Matching selects how Requests are matched against recorded Interactions during replay.
+type Matching int
+
+const (
+	// MatchStrict requires the Requests in recorded order with the same Method, URI and JSON-Body.
+	// Every Interaction is replayed once.
+	MatchStrict Matching = iota
+	// MatchLenient accepts the Requests in any order and ignores Scheme, Host, the case of the Path
+	// and the order of Query-Parameters. Interactions may be replayed several times.
+	MatchLenient
+)
+
+// Interaction is a recorded pair of Request and Response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded HTTP-Request.
+type Request struct {
+	Method string      `json:"method"`
+	URI    string      `json:"uri"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded HTTP-Response.
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// cassetteFile is the Content of a Cassette.
+type cassetteFile struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// recordedHeaders are the Headers kept in a Cassette, all others are dropped.
+var recordedHeaders = []string{"Content-Type", "Accept", "Retry-After", "Location"}
+
+// Recorder is a http.RoundTripper which records Interactions to or replays them from a Cassette file.
+type Recorder struct {
+	// Matching is used during replay. Default is MatchStrict.
+	Matching Matching
+	// Transport sends the Requests while recording. Default is http.DefaultTransport.
+	Transport http.RoundTripper
+
+	path         string
+	mode         Mode
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+	next         int
+}
+
+// New returns a Pointer to a Recorder for the Cassette at the given path. In ModeReplay the Cassette must exist.
+// In ModeRecord an existing Cassette is overwritten.
+func New(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode}
+	if mode == ModeAuto {
+		r.mode = ModeRecord
+		if _, err := os.Stat(path); err == nil {
+			r.mode = ModeReplay
+		}
+	}
+	if r.mode == ModeRecord {
+		return r, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := cassetteFile{}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+	r.interactions = c.Interactions
+	r.used = make([]bool, len(c.Interactions))
+	return r, nil
+}
+
+// Mode returns the Mode the Recorder operates in, ModeAuto is already resolved.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Option returns a gocherwell.Option which routes all HTTP-Requests of a Client through the Recorder.
+func (r *Recorder) Option() gocherwell.Option {
+	return gocherwell.WithMiddleware(r.Middleware)
+}
+
+// Middleware implements gocherwell.Middleware. While recording the Requests are sent with next.
+func (r *Recorder) Middleware(next http.RoundTripper) http.RoundTripper {
+	return gocherwell.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return r.roundTrip(req, next)
+	})
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	return r.roundTrip(req, r.Transport)
+}
+
+// Interactions returns all recorded Interactions.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction{}, r.interactions...)
+}
+
+// Unused returns the Interactions that were not replayed, e.g. to assert that a test made all expected Requests.
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res := []Interaction{}
+	for i, used := range r.used {
+		if !used {
+			res = append(res, r.interactions[i])
+		}
+	}
+	return res
+}
+
+// roundTrip records or replays a single Request
+func (r *Recorder) roundTrip(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+	body := []byte{}
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	recReq := Request{
+		Method: strings.ToUpper(req.Method),
+		URI:    gocherwell.Redact(req.URL.String()),
+		Header: filterHeader(req.Header),
+		Body:   scrub(string(body)),
+	}
+	if r.mode == ModeReplay {
+		return r.replay(req, recReq)
+	}
+
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, Interaction{
+		Request: recReq,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     filterHeader(resp.Header),
+			Body:       scrubResponse(req.URL.Path, string(data)),
+		},
+	})
+	r.used = append(r.used, true)
+	if err := r.save(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// replay returns the Response of the first unused Interaction matching the Request
+func (r *Recorder) replay(req *http.Request, recReq Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	idx := -1
+	switch r.Matching {
+	case MatchLenient:
+		for i, in := range r.interactions {
+			if matchLenient(in.Request, recReq) {
+				if !r.used[i] {
+					idx = i
+					break
+				}
+				if idx < 0 {
+					idx = i
+				}
+			}
+		}
+	default:
+		if r.next < len(r.interactions) && matchStrict(r.interactions[r.next].Request, recReq) {
+			idx = r.next
+			r.next++
+		}
+	}
+	if idx < 0 {
+		return nil, fmt.Errorf("%w: %v %v", ErrNoInteraction, recReq.Method, recReq.URI)
+	}
+	r.used[idx] = true
+
+	in := r.interactions[idx].Response
+	header := http.Header{}
+	for k, v := range in.Header {
+		header[k] = append([]string{}, v...)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", in.StatusCode, http.StatusText(in.StatusCode)),
+		StatusCode:    in.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(in.Body)),
+		ContentLength: int64(len(in.Body)),
+		Request:       req,
+	}, nil
+}
+
+// save writes all Interactions to the Cassette
+func (r *Recorder) save() error {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(cassetteFile{Interactions: r.interactions}); err != nil {
+		return err
+	}
+	if dir := filepath.Dir(r.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	return atomicfile.WriteFile(r.path, buf.Bytes(), 0o600)
+}
+
+// matchStrict compares Method, URI and Body of two Requests
+func matchStrict(a, b Request) bool {
+	return a.Method == b.Method && a.URI == b.URI && equalBody(a.Body, b.Body)
+}
+
+// matchLenient compares Method, Path, Query and Body of two Requests ignoring Scheme, Host and case of the Path
+func matchLenient(a, b Request) bool {
+	if a.Method != b.Method || !equalBody(a.Body, b.Body) {
+		return false
+	}
+	ua, err := url.Parse(a.URI)
+	if err != nil {
+		return false
+	}
+	ub, err := url.Parse(b.URI)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(ua.Path, ub.Path) && reflect.DeepEqual(ua.Query(), ub.Query())
+}
+
+// equalBody compares two Bodies as JSON if both are JSON and as Strings otherwise
+func equalBody(a, b string) bool {
+	if a == b {
+		return true
+	}
+	var ja, jb interface{}
+	if json.Unmarshal([]byte(a), &ja) != nil || json.Unmarshal([]byte(b), &jb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(ja, jb)
+}
+
+// filterHeader returns a copy of the recordedHeaders
+func filterHeader(h http.Header) http.Header {
+	res := http.Header{}
+	for _, k := range recordedHeaders {
+		if v, ok := h[k]; ok {
+			res[k] = append([]string{}, v...)
+		}
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
+
+// scrub removes Passwords and Tokens from a Request- or Response-Body
+func scrub(body string) string {
+	return gocherwell.Redact(body)
+}
+
+// scrubResponse scrubs a Response-Body. The Timestamps of Token-Responses are dropped as well,
+// so replayed Tokens expire relative to the time of the replay by their expires_in.
+func scrubResponse(path, body string) string {
+	if !strings.HasSuffix(strings.ToLower(path), "/token") {
+		return scrub(body)
+	}
+	token := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(body), &token); err != nil {
+		return scrub(body)
+	}
+	delete(token, ".issued")
+	delete(token, ".expires")
+	data, err := json.Marshal(token)
+	if err != nil {
+		return scrub(body)
+	}
+	return scrub(string(data))
+}
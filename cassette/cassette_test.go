@@ -0,0 +1,201 @@
+package cassette_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/itsscb/gocherwell"
+	"github.com/itsscb/gocherwell/cassette"
+	"github.com/itsscb/gocherwell/cherwelltest"
+)
+
+// newServer returns a Server with a BusinessObject of 5 Records.
+func newServer() *cherwelltest.Server {
+	records := []map[string]string{}
+	for i := 0; i < 5; i++ {
+		records = append(records, map[string]string{"AssetName": fmt.Sprintf("NB%03d", i)})
+	}
+	return cherwelltest.NewServer(cherwelltest.BusinessObject{
+		Name:    "ConfigComputer",
+		Fields:  []cherwelltest.Field{{Name: "AssetName"}},
+		Records: records,
+	})
+}
+
+// run resolves the BusinessObject and returns the AssetNames of all Records read in Pages of 2
+func run(ctx context.Context, cl *gocherwell.Client) ([]string, error) {
+	bo, err := cl.ResolveBusinessObject(ctx, "ConfigComputer")
+	if err != nil {
+		return nil, err
+	}
+	recs, err := bo.NewQuery().PageSize(2).Records(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, r := range *recs {
+		names = append(names, fmt.Sprint(r.FieldValues["AssetName"]))
+	}
+	return names, nil
+}
+
+// record records run against a new Server to a Cassette in dir and returns its Path, the Server URL and the Token
+func record(t *testing.T, dir string) (string, string, *gocherwell.Token) {
+	t.Helper()
+	srv := newServer()
+	defer srv.Close()
+	path := filepath.Join(dir, "fixtures", "run.json")
+	rec, err := cassette.New(path, cassette.ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl := srv.NewClient(rec.Option())
+	if _, err := run(context.Background(), cl); err != nil {
+		t.Fatal(err)
+	}
+	return path, srv.URL, cl.Token()
+}
+
+func TestRecordReplay(t *testing.T) {
+	tests := []struct {
+		name     string
+		matching cassette.Matching
+		host     string
+		err      error
+	}{
+		{"strict", cassette.MatchStrict, "", nil},
+		{"strict on other host", cassette.MatchStrict, "http://cherwell.example.com", cassette.ErrNoInteraction},
+		{"lenient on other host", cassette.MatchLenient, "http://cherwell.example.com", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "cassette")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			path, url, _ := record(t, dir)
+			if tt.host != "" {
+				url = tt.host
+			}
+
+			rec, err := cassette.New(path, cassette.ModeAuto)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rec.Mode() != cassette.ModeReplay {
+				t.Errorf("Mode() = %v, want ModeReplay for an existing Cassette", rec.Mode())
+			}
+			rec.Matching = tt.matching
+			cl := gocherwell.NewClient(cherwelltest.DefaultUser, cherwelltest.DefaultPassword, cherwelltest.DefaultClientID, url+"/", "Internal", "password",
+				rec.Option(), gocherwell.WithRetryPolicy(gocherwell.RetryPolicy{MaxAttempts: 1}))
+			names, err := run(context.Background(), cl)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("run() error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if strings.Join(names, ",") != "NB000,NB001,NB002,NB003,NB004" {
+				t.Errorf("run() = %v, want all 5 Records", names)
+			}
+			if unused := rec.Unused(); len(unused) != 0 {
+				t.Errorf("Unused() = %v, want all Interactions replayed", unused)
+			}
+		})
+	}
+}
+
+func TestRecordScrubsSecrets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cassette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path, _, token := record(t, dir)
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"password=" + cherwelltest.DefaultPassword, token.AccessToken, token.RefreshToken, ".expires", "Authorization"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("Cassette contains %q", secret)
+		}
+	}
+	if !strings.Contains(string(data), "[REDACTED]") {
+		t.Errorf("Cassette contains no redacted Value")
+	}
+}
+
+func TestReplayStrictOrder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cassette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path, url, _ := record(t, dir)
+
+	tests := []struct {
+		matching cassette.Matching
+		err      error
+	}{
+		{cassette.MatchStrict, cassette.ErrNoInteraction},
+		{cassette.MatchLenient, nil},
+	}
+	for _, tt := range tests {
+		rec, err := cassette.New(path, cassette.ModeReplay)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec.Matching = tt.matching
+		cl := gocherwell.NewClient(cherwelltest.DefaultUser, cherwelltest.DefaultPassword, cherwelltest.DefaultClientID, url+"/", "Internal", "password",
+			rec.Option(), gocherwell.WithRetryPolicy(gocherwell.RetryPolicy{MaxAttempts: 1}))
+		// The recorded run resolved the BusinessObject first, so the Schema is out of order.
+		_, err = cl.GetBusinessObjectSchema(context.Background(), &gocherwell.BusinessObject{BusObID: "BOConfigComputer"})
+		if !errors.Is(err, tt.err) {
+			t.Errorf("Matching %v: GetBusinessObjectSchema() error = %v, want %v", tt.matching, err, tt.err)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cassette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := ioutil.WriteFile(invalid, []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		path    string
+		mode    cassette.Mode
+		want    cassette.Mode
+		wantErr bool
+	}{
+		{"record", filepath.Join(dir, "new.json"), cassette.ModeRecord, cassette.ModeRecord, false},
+		{"auto without cassette", filepath.Join(dir, "new.json"), cassette.ModeAuto, cassette.ModeRecord, false},
+		{"replay without cassette", filepath.Join(dir, "new.json"), cassette.ModeReplay, cassette.ModeReplay, true},
+		{"replay of invalid cassette", invalid, cassette.ModeReplay, cassette.ModeReplay, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, err := cassette.New(tt.path, tt.mode)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && rec.Mode() != tt.want {
+				t.Errorf("Mode() = %v, want %v", rec.Mode(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,714 @@
+// cherwellmock provides an in-memory mock of gocherwell.Service for unit tests of code using gocherwell.
+package cherwellmock
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/itsscb/gocherwell"
+)
+
+var _ gocherwell.Service = (*Mock)(nil)
+
+// TestingT is the Part of testing.T used by the Assertions of Mock.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Call is a recorded call of a Method of Mock.
+type Call struct {
+	Method string
+	// Args are the Arguments of the call without the Context.
+	Args []interface{}
+}
+
+// Mock implements gocherwell.Service in memory and records all calls.
+// Every Method can be replaced by setting the corresponding Func, otherwise it operates on the
+// BusinessObjects and Records added with AddBusinessObject and AddRecord.
+type Mock struct {
+	GetBusinessObjectByDisplayNameFunc      func(ctx context.Context, displayName string) (*gocherwell.BusinessObject, error)
+	GetBusinessObjectByBusObIDFunc          func(ctx context.Context, busObID string) (*gocherwell.BusinessObject, error)
+	GetBusinessObjectByNameFunc             func(ctx context.Context, name string) (*gocherwell.BusinessObject, error)
+	GetBusinessObjectsByTypeFunc            func(ctx context.Context, typ gocherwell.BusinessObjectType) (*[]gocherwell.BusinessObject, error)
+	ResolveBusinessObjectFunc               func(ctx context.Context, id string) (*gocherwell.BusinessObject, error)
+	GetBusinessObjectSchemaFunc             func(ctx context.Context, bo *gocherwell.BusinessObject) (*gocherwell.BusinessObjectSchema, error)
+	GetBusinessObjectRecordByPublicIDFunc   func(ctx context.Context, bo *gocherwell.BusinessObject, publicID string) (*gocherwell.BusinessObjectRecord, error)
+	GetBusinessObjectRecordByRecIDFunc      func(ctx context.Context, bo *gocherwell.BusinessObject, recID string) (*gocherwell.BusinessObjectRecord, error)
+	NewBusinessObjectRecordFunc             func(ctx context.Context, bo *gocherwell.BusinessObject, fields []gocherwell.Field) (*gocherwell.BusinessObjectRecord, error)
+	SaveBusinessObjectRecordFunc            func(ctx context.Context, rec *gocherwell.BusinessObjectRecord) (*gocherwell.BusinessObjectRecord, error)
+	DeleteBusinessObjectRecordFunc          func(ctx context.Context, rec *gocherwell.BusinessObjectRecord) (*gocherwell.BusinessObjectRecord, error)
+	SearchBusinessObjectRecordFunc          func(ctx context.Context, bo *gocherwell.BusinessObject, filters ...[]string) (*gocherwell.BusinessObjectRecord, error)
+	SearchOneBusinessObjectRecordFunc       func(ctx context.Context, bo *gocherwell.BusinessObject, filters ...[]string) (*gocherwell.BusinessObjectRecord, error)
+	SearchMultipleBusinessObjectRecordsFunc func(ctx context.Context, bo *gocherwell.BusinessObject, filters ...[]string) (*[]gocherwell.BusinessObjectRecord, error)
+	QuickSearchFunc                         func(ctx context.Context, text string, busObs ...*gocherwell.BusinessObject) (*gocherwell.SimpleResults, error)
+	GetRelatedBusinessObjectsFunc           func(ctx context.Context, rec *gocherwell.BusinessObjectRecord, relationshipName string) (*[]gocherwell.BusinessObjectRecord, error)
+	LinkBusinessObjectRecordFunc            func(ctx context.Context, parent, child *gocherwell.BusinessObjectRecord, relationshipName string) error
+	UnlinkBusinessObjectRecordFunc          func(ctx context.Context, parent, child *gocherwell.BusinessObjectRecord, relationshipName string) error
+
+	mu      sync.Mutex
+	calls   []Call
+	objects []gocherwell.BusinessObject
+	schemas map[string]gocherwell.BusinessObjectSchema
+	records map[string][]gocherwell.BusinessObjectRecord
+	links   map[link]bool
+	nextID  int
+}
+
+// link identifies a link of two Records by a Relationship.
+type link struct {
+	parent       string
+	relationship string
+	child        string
+}
+
+// New returns a Pointer to an empty Mock.
+func New() *Mock {
+	return &Mock{
+		schemas: make(map[string]gocherwell.BusinessObjectSchema),
+		records: make(map[string][]gocherwell.BusinessObjectRecord),
+		links:   make(map[link]bool),
+	}
+}
+
+// AddBusinessObject adds a BusinessObject and optionally its Schema to the Mock.
+func (m *Mock) AddBusinessObject(bo gocherwell.BusinessObject, schema *gocherwell.BusinessObjectSchema) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.init()
+	m.objects = append(m.objects, bo)
+	if schema != nil {
+		m.schemas[bo.BusObID] = *schema
+	}
+}
+
+// AddRecord adds a Record to the Mock. Its FieldValues are derived from its Fields if they are not set.
+func (m *Mock) AddRecord(rec gocherwell.BusinessObjectRecord) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.init()
+	m.records[rec.BusObID] = append(m.records[rec.BusObID], normalize(rec))
+}
+
+// Records returns the current Records of the BusinessObject with the given BusObID.
+func (m *Mock) Records(busObID string) []gocherwell.BusinessObjectRecord {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]gocherwell.BusinessObjectRecord{}, m.records[busObID]...)
+}
+
+// Calls returns all calls of the given Method or of all Methods if method is empty.
+func (m *Mock) Calls(method string) []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	res := []Call{}
+	for _, c := range m.calls {
+		if method == "" || c.Method == method {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// Reset forgets all recorded calls.
+func (m *Mock) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+}
+
+// AssertCalled fails the test if the Method was not called with the given Arguments.
+// Without Arguments any call of the Method matches.
+func (m *Mock) AssertCalled(t TestingT, method string, args ...interface{}) bool {
+	t.Helper()
+	for _, c := range m.Calls(method) {
+		if len(args) == 0 || reflect.DeepEqual(c.Args[:min(len(args), len(c.Args))], args) {
+			return true
+		}
+	}
+	t.Errorf("cherwellmock: expected call of %v%v, got %v", method, args, m.Calls(method))
+	return false
+}
+
+// AssertNotCalled fails the test if the Method was called.
+func (m *Mock) AssertNotCalled(t TestingT, method string) bool {
+	t.Helper()
+	if calls := m.Calls(method); len(calls) > 0 {
+		t.Errorf("cherwellmock: expected no call of %v, got %v", method, calls)
+		return false
+	}
+	return true
+}
+
+// AssertNumberOfCalls fails the test if the Method was not called exactly n times.
+func (m *Mock) AssertNumberOfCalls(t TestingT, method string, n int) bool {
+	t.Helper()
+	if calls := m.Calls(method); len(calls) != n {
+		t.Errorf("cherwellmock: expected %v calls of %v, got %v", n, method, len(calls))
+		return false
+	}
+	return true
+}
+
+// GetBusinessObjectByDisplayName implements gocherwell.MetadataService.
+func (m *Mock) GetBusinessObjectByDisplayName(ctx context.Context, displayName string) (*gocherwell.BusinessObject, error) {
+	m.record("GetBusinessObjectByDisplayName", displayName)
+	if m.GetBusinessObjectByDisplayNameFunc != nil {
+		return m.GetBusinessObjectByDisplayNameFunc(ctx, displayName)
+	}
+	return m.businessObject(func(bo gocherwell.BusinessObject) bool { return bo.DisplayName == displayName }, displayName)
+}
+
+// GetBusinessObjectByBusObID implements gocherwell.MetadataService.
+func (m *Mock) GetBusinessObjectByBusObID(ctx context.Context, busObID string) (*gocherwell.BusinessObject, error) {
+	m.record("GetBusinessObjectByBusObID", busObID)
+	if m.GetBusinessObjectByBusObIDFunc != nil {
+		return m.GetBusinessObjectByBusObIDFunc(ctx, busObID)
+	}
+	return m.businessObject(func(bo gocherwell.BusinessObject) bool { return bo.BusObID == busObID }, busObID)
+}
+
+// GetBusinessObjectByName implements gocherwell.MetadataService.
+func (m *Mock) GetBusinessObjectByName(ctx context.Context, name string) (*gocherwell.BusinessObject, error) {
+	m.record("GetBusinessObjectByName", name)
+	if m.GetBusinessObjectByNameFunc != nil {
+		return m.GetBusinessObjectByNameFunc(ctx, name)
+	}
+	return m.businessObject(func(bo gocherwell.BusinessObject) bool { return bo.Name == name }, name)
+}
+
+// GetBusinessObjectsByType implements gocherwell.MetadataService.
+func (m *Mock) GetBusinessObjectsByType(ctx context.Context, typ gocherwell.BusinessObjectType) (*[]gocherwell.BusinessObject, error) {
+	m.record("GetBusinessObjectsByType", typ)
+	if m.GetBusinessObjectsByTypeFunc != nil {
+		return m.GetBusinessObjectsByTypeFunc(ctx, typ)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	res := []gocherwell.BusinessObject{}
+	for _, bo := range m.objects {
+		switch typ {
+		case gocherwell.BusinessObjectTypeMajor:
+			if !bo.Major {
+				continue
+			}
+		case gocherwell.BusinessObjectTypeSupporting:
+			if !bo.Supporting {
+				continue
+			}
+		case gocherwell.BusinessObjectTypeLookup:
+			if !bo.Lookup {
+				continue
+			}
+		case gocherwell.BusinessObjectTypeGroups:
+			if !bo.Group {
+				continue
+			}
+		}
+		res = append(res, bo)
+	}
+	return &res, nil
+}
+
+// ResolveBusinessObject implements gocherwell.MetadataService.
+func (m *Mock) ResolveBusinessObject(ctx context.Context, id string) (*gocherwell.BusinessObject, error) {
+	m.record("ResolveBusinessObject", id)
+	if m.ResolveBusinessObjectFunc != nil {
+		return m.ResolveBusinessObjectFunc(ctx, id)
+	}
+	return m.businessObject(func(bo gocherwell.BusinessObject) bool {
+		return strings.EqualFold(bo.BusObID, id) || strings.EqualFold(bo.Name, id) || bo.DisplayName == id
+	}, id)
+}
+
+// GetBusinessObjectSchema implements gocherwell.MetadataService.
+func (m *Mock) GetBusinessObjectSchema(ctx context.Context, bo *gocherwell.BusinessObject) (*gocherwell.BusinessObjectSchema, error) {
+	m.record("GetBusinessObjectSchema", bo)
+	if m.GetBusinessObjectSchemaFunc != nil {
+		return m.GetBusinessObjectSchemaFunc(ctx, bo)
+	}
+	if bo == nil {
+		return nil, fmt.Errorf("%w: BusinessObject", gocherwell.ErrNilReceiver)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sch, ok := m.schemas[bo.BusObID]
+	if !ok {
+		return nil, fmt.Errorf("%w: BusinessObjectSchema: %v", gocherwell.ErrNotFound, bo.BusObID)
+	}
+	return &sch, nil
+}
+
+// GetBusinessObjectRecordByPublicID implements gocherwell.RecordService.
+func (m *Mock) GetBusinessObjectRecordByPublicID(ctx context.Context, bo *gocherwell.BusinessObject, publicID string) (*gocherwell.BusinessObjectRecord, error) {
+	m.record("GetBusinessObjectRecordByPublicID", bo, publicID)
+	if m.GetBusinessObjectRecordByPublicIDFunc != nil {
+		return m.GetBusinessObjectRecordByPublicIDFunc(ctx, bo, publicID)
+	}
+	return m.findRecord(bo, func(rec gocherwell.BusinessObjectRecord) bool { return rec.BusObPublicID == publicID }, publicID)
+}
+
+// GetBusinessObjectRecordByRecID implements gocherwell.RecordService.
+func (m *Mock) GetBusinessObjectRecordByRecID(ctx context.Context, bo *gocherwell.BusinessObject, recID string) (*gocherwell.BusinessObjectRecord, error) {
+	m.record("GetBusinessObjectRecordByRecID", bo, recID)
+	if m.GetBusinessObjectRecordByRecIDFunc != nil {
+		return m.GetBusinessObjectRecordByRecIDFunc(ctx, bo, recID)
+	}
+	return m.findRecord(bo, func(rec gocherwell.BusinessObjectRecord) bool { return rec.BusObRecID == recID }, recID)
+}
+
+// NewBusinessObjectRecord implements gocherwell.RecordService.
+func (m *Mock) NewBusinessObjectRecord(ctx context.Context, bo *gocherwell.BusinessObject, fields []gocherwell.Field) (*gocherwell.BusinessObjectRecord, error) {
+	m.record("NewBusinessObjectRecord", bo, fields)
+	if m.NewBusinessObjectRecordFunc != nil {
+		return m.NewBusinessObjectRecordFunc(ctx, bo, fields)
+	}
+	if bo == nil {
+		return nil, fmt.Errorf("%w: BusinessObject", gocherwell.ErrNilReceiver)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.init()
+	m.nextID++
+	id := strconv.Itoa(m.nextID)
+	rec := normalize(gocherwell.BusinessObjectRecord{
+		BusObID:       bo.BusObID,
+		BusObRecID:    "REC" + id,
+		BusObPublicID: id,
+		Fields:        append([]gocherwell.Field{}, fields...),
+	})
+	m.records[bo.BusObID] = append(m.records[bo.BusObID], rec)
+	return copyRecord(rec), nil
+}
+
+// SaveBusinessObjectRecord implements gocherwell.RecordService.
+func (m *Mock) SaveBusinessObjectRecord(ctx context.Context, rec *gocherwell.BusinessObjectRecord) (*gocherwell.BusinessObjectRecord, error) {
+	m.record("SaveBusinessObjectRecord", rec)
+	if m.SaveBusinessObjectRecordFunc != nil {
+		return m.SaveBusinessObjectRecordFunc(ctx, rec)
+	}
+	if rec == nil {
+		return nil, fmt.Errorf("%w: BusinessObjectRecord", gocherwell.ErrNilReceiver)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.init()
+	saved := copyRecord(*rec)
+	// Like the Client, Keys of FieldValues are FieldIDs, Names or DisplayNames and only changed Values are applied.
+	original := make([]string, len(saved.Fields))
+	for i, f := range saved.Fields {
+		original[i] = f.Value
+	}
+	names := make([]string, 0, len(saved.FieldValues))
+	for name := range saved.FieldValues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		i := fieldIndex(saved.Fields, name)
+		if i < 0 {
+			return nil, fmt.Errorf("%w: Field: %v", gocherwell.ErrNotFound, name)
+		}
+		var def *gocherwell.FieldDefinition
+		if sch, ok := m.schemas[rec.BusObID]; ok {
+			def, _ = sch.FieldDefinition(saved.Fields[i].FieldID)
+		}
+		value := gocherwell.FormatValue(def, saved.FieldValues[name])
+		if i >= len(original) || value != original[i] {
+			saved.Fields[i].Value = value
+		}
+	}
+	saved.FieldValues = nil
+	// Like Cherwell, a Record without BusObRecID is created.
+	if saved.BusObRecID == "" {
+		m.nextID++
+		id := strconv.Itoa(m.nextID)
+		saved.BusObRecID = "REC" + id
+		if saved.BusObPublicID == "" {
+			saved.BusObPublicID = id
+		}
+		created := normalize(*saved)
+		m.records[rec.BusObID] = append(m.records[rec.BusObID], created)
+		return copyRecord(created), nil
+	}
+	records := m.records[rec.BusObID]
+	for i, r := range records {
+		if r.BusObRecID == rec.BusObRecID {
+			records[i] = normalize(*saved)
+			return copyRecord(records[i]), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: BusinessObjectRecord: %v", gocherwell.ErrNotFound, rec.BusObRecID)
+}
+
+// DeleteBusinessObjectRecord implements gocherwell.RecordService.
+func (m *Mock) DeleteBusinessObjectRecord(ctx context.Context, rec *gocherwell.BusinessObjectRecord) (*gocherwell.BusinessObjectRecord, error) {
+	m.record("DeleteBusinessObjectRecord", rec)
+	if m.DeleteBusinessObjectRecordFunc != nil {
+		return m.DeleteBusinessObjectRecordFunc(ctx, rec)
+	}
+	if rec == nil {
+		return nil, fmt.Errorf("%w: BusinessObjectRecord", gocherwell.ErrNilReceiver)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	records := m.records[rec.BusObID]
+	for i, r := range records {
+		if r.BusObRecID == rec.BusObRecID {
+			m.records[rec.BusObID] = append(records[:i:i], records[i+1:]...)
+			return copyRecord(r), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: BusinessObjectRecord: %v", gocherwell.ErrNotFound, rec.BusObRecID)
+}
+
+// SearchBusinessObjectRecord implements gocherwell.RecordService.
+func (m *Mock) SearchBusinessObjectRecord(ctx context.Context, bo *gocherwell.BusinessObject, filters ...[]string) (*gocherwell.BusinessObjectRecord, error) {
+	m.record("SearchBusinessObjectRecord", bo, filters)
+	if m.SearchBusinessObjectRecordFunc != nil {
+		return m.SearchBusinessObjectRecordFunc(ctx, bo, filters...)
+	}
+	res, err := m.search(bo, filters)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%w: BusinessObjectRecord: %v", gocherwell.ErrNotFound, filters)
+	}
+	return &res[0], nil
+}
+
+// SearchOneBusinessObjectRecord implements gocherwell.RecordService.
+func (m *Mock) SearchOneBusinessObjectRecord(ctx context.Context, bo *gocherwell.BusinessObject, filters ...[]string) (*gocherwell.BusinessObjectRecord, error) {
+	m.record("SearchOneBusinessObjectRecord", bo, filters)
+	if m.SearchOneBusinessObjectRecordFunc != nil {
+		return m.SearchOneBusinessObjectRecordFunc(ctx, bo, filters...)
+	}
+	res, err := m.search(bo, filters)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%w: BusinessObjectRecord: %v", gocherwell.ErrNotFound, filters)
+	}
+	if len(res) > 1 {
+		return nil, fmt.Errorf("%w: BusinessObjectRecord: %v", gocherwell.ErrMultipleResults, filters)
+	}
+	return &res[0], nil
+}
+
+// SearchMultipleBusinessObjectRecords implements gocherwell.RecordService.
+func (m *Mock) SearchMultipleBusinessObjectRecords(ctx context.Context, bo *gocherwell.BusinessObject, filters ...[]string) (*[]gocherwell.BusinessObjectRecord, error) {
+	m.record("SearchMultipleBusinessObjectRecords", bo, filters)
+	if m.SearchMultipleBusinessObjectRecordsFunc != nil {
+		return m.SearchMultipleBusinessObjectRecordsFunc(ctx, bo, filters...)
+	}
+	res, err := m.search(bo, filters)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// QuickSearch implements gocherwell.RecordService. It returns the Records with a FieldValue containing
+// the Text, grouped by BusinessObject. Without BusinessObjects all BusinessObjects are searched.
+func (m *Mock) QuickSearch(ctx context.Context, text string, busObs ...*gocherwell.BusinessObject) (*gocherwell.SimpleResults, error) {
+	m.record("QuickSearch", text, busObs)
+	if m.QuickSearchFunc != nil {
+		return m.QuickSearchFunc(ctx, text, busObs...)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	objects := []gocherwell.BusinessObject{}
+	for _, bo := range busObs {
+		if bo == nil {
+			return nil, fmt.Errorf("%w: BusinessObject", gocherwell.ErrNilReceiver)
+		}
+		objects = append(objects, *bo)
+	}
+	if len(busObs) == 0 {
+		objects = append(objects, m.objects...)
+	}
+	res := gocherwell.SimpleResults{Title: text}
+	for _, bo := range objects {
+		group := gocherwell.SimpleResultsGroup{IsBusObTarget: true, TargetID: bo.BusObID, Title: bo.DisplayName}
+		for _, rec := range m.records[bo.BusObID] {
+			names := make([]string, 0, len(rec.FieldValues))
+			for name := range rec.FieldValues {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				v := rec.FieldValues[name]
+				if strings.Contains(strings.ToLower(fmt.Sprint(v)), strings.ToLower(text)) {
+					group.SimpleResultsListItems = append(group.SimpleResultsListItems, gocherwell.SimpleResultsListItem{
+						BusinessObjectRecord: gocherwell.BusinessObjectRecord{BusObID: rec.BusObID, BusObRecID: rec.BusObRecID, BusObPublicID: rec.BusObPublicID},
+						PublicID:             rec.BusObPublicID,
+						Title:                rec.BusObPublicID,
+						SubTitle:             bo.DisplayName,
+						Text:                 fmt.Sprint(v),
+					})
+					break
+				}
+			}
+		}
+		if len(group.SimpleResultsListItems) > 0 {
+			group.SubTitle = strconv.Itoa(len(group.SimpleResultsListItems))
+			res.Groups = append(res.Groups, group)
+		}
+	}
+	return &res, nil
+}
+
+// GetRelatedBusinessObjects implements gocherwell.RelationshipService.
+func (m *Mock) GetRelatedBusinessObjects(ctx context.Context, rec *gocherwell.BusinessObjectRecord, relationshipName string) (*[]gocherwell.BusinessObjectRecord, error) {
+	m.record("GetRelatedBusinessObjects", rec, relationshipName)
+	if m.GetRelatedBusinessObjectsFunc != nil {
+		return m.GetRelatedBusinessObjectsFunc(ctx, rec, relationshipName)
+	}
+	if rec == nil {
+		return nil, fmt.Errorf("%w: BusinessObjectRecord", gocherwell.ErrNilReceiver)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	res := []gocherwell.BusinessObjectRecord{}
+	for _, records := range m.records {
+		for _, r := range records {
+			if m.links[link{parent: rec.BusObRecID, relationship: relationshipName, child: r.BusObRecID}] {
+				res = append(res, *copyRecord(r))
+			}
+		}
+	}
+	return &res, nil
+}
+
+// LinkBusinessObjectRecord implements gocherwell.RelationshipService.
+func (m *Mock) LinkBusinessObjectRecord(ctx context.Context, parent, child *gocherwell.BusinessObjectRecord, relationshipName string) error {
+	m.record("LinkBusinessObjectRecord", parent, child, relationshipName)
+	if m.LinkBusinessObjectRecordFunc != nil {
+		return m.LinkBusinessObjectRecordFunc(ctx, parent, child, relationshipName)
+	}
+	if parent == nil || child == nil {
+		return fmt.Errorf("%w: BusinessObjectRecord", gocherwell.ErrNilReceiver)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.init()
+	m.links[link{parent: parent.BusObRecID, relationship: relationshipName, child: child.BusObRecID}] = true
+	return nil
+}
+
+// UnlinkBusinessObjectRecord implements gocherwell.RelationshipService.
+func (m *Mock) UnlinkBusinessObjectRecord(ctx context.Context, parent, child *gocherwell.BusinessObjectRecord, relationshipName string) error {
+	m.record("UnlinkBusinessObjectRecord", parent, child, relationshipName)
+	if m.UnlinkBusinessObjectRecordFunc != nil {
+		return m.UnlinkBusinessObjectRecordFunc(ctx, parent, child, relationshipName)
+	}
+	if parent == nil || child == nil {
+		return fmt.Errorf("%w: BusinessObjectRecord", gocherwell.ErrNilReceiver)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.links, link{parent: parent.BusObRecID, relationship: relationshipName, child: child.BusObRecID})
+	return nil
+}
+
+// record records a call
+func (m *Mock) record(method string, args ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+}
+
+// init initializes the Maps of a Mock not created by New
+func (m *Mock) init() {
+	if m.schemas == nil {
+		m.schemas = make(map[string]gocherwell.BusinessObjectSchema)
+	}
+	if m.records == nil {
+		m.records = make(map[string][]gocherwell.BusinessObjectRecord)
+	}
+	if m.links == nil {
+		m.links = make(map[link]bool)
+	}
+}
+
+// businessObject returns the first BusinessObject matching the given function
+func (m *Mock) businessObject(match func(gocherwell.BusinessObject) bool, name string) (*gocherwell.BusinessObject, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, bo := range m.objects {
+		if match(bo) {
+			return &bo, nil
+		}
+		for _, c := range bo.GroupSummaries {
+			if match(c) {
+				return &c, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: BusinessObject: %v", gocherwell.ErrNotFound, name)
+}
+
+// findRecord returns a copy of the first Record of the BusinessObject matching the given function
+func (m *Mock) findRecord(bo *gocherwell.BusinessObject, match func(gocherwell.BusinessObjectRecord) bool, id string) (*gocherwell.BusinessObjectRecord, error) {
+	if bo == nil {
+		return nil, fmt.Errorf("%w: BusinessObject", gocherwell.ErrNilReceiver)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, rec := range m.records[bo.BusObID] {
+		if match(rec) {
+			return copyRecord(rec), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: BusinessObjectRecord: %v", gocherwell.ErrNotFound, id)
+}
+
+// search returns copies of all Records of the BusinessObject matching the Filters. Filters on the same
+// Field are combined with OR, Filters on different Fields with AND like Cherwell does.
+func (m *Mock) search(bo *gocherwell.BusinessObject, filters [][]string) ([]gocherwell.BusinessObjectRecord, error) {
+	if bo == nil {
+		return nil, fmt.Errorf("%w: BusinessObject", gocherwell.ErrNilReceiver)
+	}
+	byField := map[string][][]string{}
+	for _, f := range filters {
+		if len(f) != 3 {
+			return nil, fmt.Errorf("%w: Filter invalid: want ['FieldDisplayName','Operator','Value'], got %v", gocherwell.ErrValidation, f)
+		}
+		byField[f[0]] = append(byField[f[0]], f)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for field := range byField {
+		if err := m.knownField(bo.BusObID, field); err != nil {
+			return nil, err
+		}
+	}
+	res := []gocherwell.BusinessObjectRecord{}
+	for _, rec := range m.records[bo.BusObID] {
+		ok := true
+		for field, fs := range byField {
+			matched := false
+			name := field
+			if rf, err := rec.Field(field); err == nil {
+				name = rf.DisplayName
+			}
+			for _, f := range fs {
+				if match(fmt.Sprint(rec.FieldValues[name]), f[1], f[2]) {
+					matched = true
+					break
+				}
+			}
+			ok = ok && matched
+		}
+		if ok {
+			res = append(res, *copyRecord(rec))
+		}
+	}
+	return res, nil
+}
+
+// knownField returns ErrNotFound like the Client if the Field is neither in the Schema of the BusinessObject
+// nor in its Records. Without Schema and Records every Field is accepted.
+func (m *Mock) knownField(busObID, field string) error {
+	if sch, ok := m.schemas[busObID]; ok {
+		_, err := sch.FieldDefinition(field)
+		return err
+	}
+	recs := m.records[busObID]
+	for _, rec := range recs {
+		if fieldIndex(rec.Fields, field) >= 0 {
+			return nil
+		}
+	}
+	if len(recs) > 0 {
+		return fmt.Errorf("%w: Field: %v", gocherwell.ErrNotFound, field)
+	}
+	return nil
+}
+
+// match evaluates a Filter-Operator against a Value
+func match(value, op, filter string) bool {
+	v, f := strings.ToLower(value), strings.ToLower(filter)
+	switch strings.ToLower(op) {
+	case "eq":
+		return v == f
+	case "contains":
+		return strings.Contains(v, f)
+	case "startswith":
+		return strings.HasPrefix(v, f)
+	case "gt", "lt":
+		x, errX := strconv.ParseFloat(value, 64)
+		y, errY := strconv.ParseFloat(filter, 64)
+		if errX != nil || errY != nil {
+			if op == "gt" {
+				return v > f
+			}
+			return v < f
+		}
+		if op == "gt" {
+			return x > y
+		}
+		return x < y
+	}
+	return false
+}
+
+// normalize derives the FieldValues of a Record from its Fields
+func normalize(rec gocherwell.BusinessObjectRecord) gocherwell.BusinessObjectRecord {
+	values := make(map[string]interface{})
+	for _, f := range rec.Fields {
+		values[f.DisplayName] = f.Value
+	}
+	for k, v := range rec.FieldValues {
+		if _, ok := values[k]; !ok {
+			values[k] = v
+		}
+	}
+	rec.FieldValues = values
+	return rec
+}
+
+// copyRecord returns a deep copy of a Record, so callers can not modify the state of the Mock
+func copyRecord(rec gocherwell.BusinessObjectRecord) *gocherwell.BusinessObjectRecord {
+	rec.Fields = append([]gocherwell.Field{}, rec.Fields...)
+	values := make(map[string]interface{}, len(rec.FieldValues))
+	for k, v := range rec.FieldValues {
+		values[k] = v
+	}
+	rec.FieldValues = values
+	return &rec
+}
+
+// fieldIndex returns the Index of the Field with the given FieldID, FullFieldID, Name or DisplayName or -1
+func fieldIndex(fields []gocherwell.Field, id string) int {
+	for i, f := range fields {
+		if (f.FieldID != "" && strings.EqualFold(f.FieldID, id)) || (f.FullFieldID != "" && strings.EqualFold(f.FullFieldID, id)) {
+			return i
+		}
+	}
+	for i, f := range fields {
+		if f.Name != "" && strings.EqualFold(f.Name, id) {
+			return i
+		}
+	}
+	for i, f := range fields {
+		if f.DisplayName == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,200 @@
+package cherwellmock_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/itsscb/gocherwell"
+	"github.com/itsscb/gocherwell/cherwellmock"
+)
+
+var computer = gocherwell.BusinessObject{BusObID: "BO1", Name: "ConfigComputer", DisplayName: "Computer", Major: true}
+
+// newMock returns a Mock with the BusinessObject computer and the Record NB001.
+func newMock() *cherwellmock.Mock {
+	m := cherwellmock.New()
+	m.AddBusinessObject(computer, nil)
+	m.AddRecord(gocherwell.BusinessObjectRecord{
+		BusObID:       "BO1",
+		BusObRecID:    "R1",
+		BusObPublicID: "NB001",
+		Fields: []gocherwell.Field{
+			{FieldID: "F1", Name: "AssetName", DisplayName: "Name", Value: "NB001"},
+			{FieldID: "F2", Name: "Status", DisplayName: "Zustand", Value: "Active"},
+			{FieldID: "F3", Name: "Cost", DisplayName: "Kosten", Value: "100"},
+		},
+	})
+	return m
+}
+
+func TestSaveBusinessObjectRecord(t *testing.T) {
+	tests := []struct {
+		name    string
+		rec     gocherwell.BusinessObjectRecord
+		records int
+		want    map[string]interface{}
+		err     error
+	}{
+		{"update by display name", gocherwell.BusinessObjectRecord{BusObRecID: "R1", FieldValues: map[string]interface{}{"Zustand": "Retired"}}, 1,
+			map[string]interface{}{"Name": "NB001", "Zustand": "Retired"}, nil},
+		{"update by name", gocherwell.BusinessObjectRecord{BusObRecID: "R1", FieldValues: map[string]interface{}{"status": "Retired"}}, 1,
+			map[string]interface{}{"Zustand": "Retired"}, nil},
+		{"update by field id", gocherwell.BusinessObjectRecord{BusObRecID: "R1", FieldValues: map[string]interface{}{"F3": 12.5}}, 1,
+			map[string]interface{}{"Kosten": "12.5"}, nil},
+		{"unchanged key does not revert", gocherwell.BusinessObjectRecord{BusObRecID: "R1", FieldValues: map[string]interface{}{"Status": "Retired", "Zustand": "Active"}}, 1,
+			map[string]interface{}{"Zustand": "Retired"}, nil},
+		{"values are formatted", gocherwell.BusinessObjectRecord{BusObRecID: "R1", FieldValues: map[string]interface{}{"Name": true, "Kosten": time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}}, 1,
+			map[string]interface{}{"Name": "True", "Kosten": "2026-01-02T03:04:05"}, nil},
+		{"create", gocherwell.BusinessObjectRecord{Fields: []gocherwell.Field{{FieldID: "F1", Name: "AssetName", DisplayName: "Name"}}, FieldValues: map[string]interface{}{"Name": "NB002"}}, 2,
+			map[string]interface{}{"Name": "NB002"}, nil},
+		{"unknown field", gocherwell.BusinessObjectRecord{BusObRecID: "R1", FieldValues: map[string]interface{}{"Owner": "alice"}}, 1,
+			nil, gocherwell.ErrNotFound},
+		{"missing record", gocherwell.BusinessObjectRecord{BusObRecID: "R2", FieldValues: map[string]interface{}{"Name": "NB002"}}, 1,
+			nil, gocherwell.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMock()
+			ctx := context.Background()
+			rec := tt.rec
+			rec.BusObID = "BO1"
+			if rec.BusObRecID != "" {
+				if existing, err := m.GetBusinessObjectRecordByRecID(ctx, &computer, rec.BusObRecID); err == nil {
+					existing.FieldValues = tt.rec.FieldValues
+					rec = *existing
+				}
+			}
+			saved, err := m.SaveBusinessObjectRecord(ctx, &rec)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("SaveBusinessObjectRecord() error = %v, want %v", err, tt.err)
+			}
+			if got := len(m.Records("BO1")); got != tt.records {
+				t.Errorf("Records = %v, want %v", got, tt.records)
+			}
+			if err != nil {
+				return
+			}
+			if saved.BusObRecID == "" {
+				t.Errorf("saved BusObRecID is empty")
+			}
+			stored, err := m.GetBusinessObjectRecordByRecID(ctx, &computer, saved.BusObRecID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for k, v := range tt.want {
+				if stored.FieldValues[k] != v {
+					t.Errorf("stored %v = %v, want %v", k, stored.FieldValues[k], v)
+				}
+			}
+			m.AssertCalled(t, "SaveBusinessObjectRecord")
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		name    string
+		filters [][]string
+		found   int
+		err     error
+	}{
+		{"eq", [][]string{{"Zustand", "eq", "active"}}, 1, nil},
+		{"by name", [][]string{{"Status", "eq", "Active"}}, 1, nil},
+		{"or on the same field", [][]string{{"Zustand", "eq", "Retired"}, {"Zustand", "eq", "Active"}}, 1, nil},
+		{"and across fields", [][]string{{"Zustand", "eq", "Active"}, {"Kosten", "gt", "200"}}, 0, gocherwell.ErrNotFound},
+		{"numbers", [][]string{{"Kosten", "gt", "20"}}, 1, nil},
+		{"contains", [][]string{{"Name", "contains", "b0"}}, 1, nil},
+		{"invalid filter", [][]string{{"Name", "eq"}}, 0, gocherwell.ErrValidation},
+		{"unknown field", [][]string{{"Owner", "eq", "alice"}}, 0, gocherwell.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMock()
+			rec, err := m.SearchOneBusinessObjectRecord(context.Background(), &computer, tt.filters...)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("SearchOneBusinessObjectRecord() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && rec.BusObRecID != "R1" {
+				t.Errorf("SearchOneBusinessObjectRecord() = %v, want R1", rec.BusObRecID)
+			}
+			m.AssertNumberOfCalls(t, "SearchOneBusinessObjectRecord", 1)
+		})
+	}
+}
+
+func TestSearchSchemaFields(t *testing.T) {
+	m := cherwellmock.New()
+	m.AddBusinessObject(computer, &gocherwell.BusinessObjectSchema{
+		BusObID:          "BO1",
+		FieldDefinitions: []gocherwell.FieldDefinition{{FieldID: "F1", Name: "AssetName", DisplayName: "Name"}},
+	})
+	ctx := context.Background()
+	if _, err := m.SearchMultipleBusinessObjectRecords(ctx, &computer, []string{"Name", "eq", "NB001"}); err != nil {
+		t.Errorf("SearchMultipleBusinessObjectRecords() of a Schema Field error = %v", err)
+	}
+	if _, err := m.SearchMultipleBusinessObjectRecords(ctx, &computer, []string{"Owner", "eq", "alice"}); !errors.Is(err, gocherwell.ErrNotFound) {
+		t.Errorf("SearchMultipleBusinessObjectRecords() of an unknown Field error = %v, want %v", err, gocherwell.ErrNotFound)
+	}
+}
+
+func TestFuncOverrides(t *testing.T) {
+	m := newMock()
+	errDown := errors.New("down")
+	m.ResolveBusinessObjectFunc = func(ctx context.Context, id string) (*gocherwell.BusinessObject, error) {
+		return nil, errDown
+	}
+	if _, err := m.ResolveBusinessObject(context.Background(), "Computer"); !errors.Is(err, errDown) {
+		t.Errorf("ResolveBusinessObject() error = %v, want the error of ResolveBusinessObjectFunc", err)
+	}
+	m.AssertCalled(t, "ResolveBusinessObject", "Computer")
+	m.AssertNotCalled(t, "SaveBusinessObjectRecord")
+}
+
+// recordingT records the Errors of the Assertions.
+type recordingT struct {
+	errors []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertions(t *testing.T) {
+	m := newMock()
+	if _, err := m.GetBusinessObjectByDisplayName(context.Background(), "Computer"); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		assert func(t cherwellmock.TestingT) bool
+		want   bool
+	}{
+		{"called", func(t cherwellmock.TestingT) bool { return m.AssertCalled(t, "GetBusinessObjectByDisplayName") }, true},
+		{"called with args", func(t cherwellmock.TestingT) bool {
+			return m.AssertCalled(t, "GetBusinessObjectByDisplayName", "Computer")
+		}, true},
+		{"called with other args", func(t cherwellmock.TestingT) bool {
+			return m.AssertCalled(t, "GetBusinessObjectByDisplayName", "Incident")
+		}, false},
+		{"not called", func(t cherwellmock.TestingT) bool { return m.AssertNotCalled(t, "GetBusinessObjectByDisplayName") }, false},
+		{"number of calls", func(t cherwellmock.TestingT) bool {
+			return m.AssertNumberOfCalls(t, "GetBusinessObjectByDisplayName", 1)
+		}, true},
+		{"wrong number of calls", func(t cherwellmock.TestingT) bool {
+			return m.AssertNumberOfCalls(t, "GetBusinessObjectByDisplayName", 2)
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := &recordingT{}
+			if got := tt.assert(rt); got != tt.want || (len(rt.errors) == 0) != tt.want {
+				t.Errorf("assertion = %v with errors %v, want %v", got, rt.errors, tt.want)
+			}
+		})
+	}
+}
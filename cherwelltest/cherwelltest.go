@@ -0,0 +1,426 @@
+// cherwelltest provides an in-memory fake of the Cherwell REST-API for offline tests
+// of gocherwell and the applications using it.
+package cherwelltest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/itsscb/gocherwell"
+)
+
+// recIDField is the Name of the Field holding the RecID, which the Server adds to every
+// BusinessObject without it like Cherwell does.
+const recIDField = "RecID"
+
+// Default Credentials accepted by the token endpoint of a Server.
+const (
+	DefaultUser     = "cherwelltest"
+	DefaultPassword = "cherwelltest"
+	DefaultClientID = "00000000-0000-0000-0000-000000000000"
+)
+
+// BusinessObject defines a Cherwell BusinessObject of a Server, including its Records.
+// It is used to seed the Server from Go values or JSON fixtures.
+type BusinessObject struct {
+	BusObID     string `json:"busObId,omitempty"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName,omitempty"`
+	// Type is one of Major, Supporting, Lookup or Group. Default is Major.
+	Type string `json:"type,omitempty"`
+	// Group is the BusObID of the Group BusinessObject this one is a member of.
+	Group string `json:"group,omitempty"`
+	// PublicIDField is the Name of the Field used as PublicID. Default is the RecID.
+	PublicIDField string         `json:"publicIdField,omitempty"`
+	StateField    string         `json:"stateField,omitempty"`
+	States        []string       `json:"states,omitempty"`
+	Fields        []Field        `json:"fields"`
+	Relationships []Relationship `json:"relationships,omitempty"`
+	Searches      []Search       `json:"searches,omitempty"`
+	// Records are the initial Records as Values by Field-Name.
+	Records []map[string]string `json:"records,omitempty"`
+}
+
+// Field defines a Field of a BusinessObject.
+type Field struct {
+	FieldID     string `json:"fieldId,omitempty"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName,omitempty"`
+	// Type is the Cherwell Field-Type, e.g. Text, Number, DateTime or Logical. Default is Text.
+	Type          string `json:"type,omitempty"`
+	Required      bool   `json:"required,omitempty"`
+	ReadOnly      bool   `json:"readOnly,omitempty"`
+	HasDate       bool   `json:"hasDate,omitempty"`
+	HasTime       bool   `json:"hasTime,omitempty"`
+	DecimalDigits int64  `json:"decimalDigits,omitempty"`
+	WholeDigits   int64  `json:"wholeDigits,omitempty"`
+	Default       string `json:"default,omitempty"`
+}
+
+// Relationship defines a Relationship of a BusinessObject to a Target BusinessObject.
+type Relationship struct {
+	RelationshipID string `json:"relationshipId,omitempty"`
+	Name           string `json:"name"`
+	DisplayName    string `json:"displayName,omitempty"`
+	// Target is the BusObID or Name of the related BusinessObject.
+	Target      string `json:"target"`
+	Cardinality string `json:"cardinality,omitempty"`
+}
+
+// Search defines a stored Search of a BusinessObject.
+type Search struct {
+	SearchID string `json:"searchId,omitempty"`
+	Name     string `json:"name"`
+	// Scope is the Scope of the Search, e.g. Global, Team or User. Default is Global.
+	Scope      string   `json:"scope,omitempty"`
+	ScopeOwner string   `json:"scopeOwner,omitempty"`
+	Filters    []Filter `json:"filters,omitempty"`
+}
+
+// Filter is a Filter of a stored Search. If Prompt is set, the Filter is a Prompt with this Text
+// and Value is its Default. A Prompt without Default is required.
+type Filter struct {
+	Field    string `json:"field"`
+	Operator string `json:"operator"`
+	Value    string `json:"value,omitempty"`
+	Prompt   string `json:"prompt,omitempty"`
+}
+
+// Server is a httptest.Server emulating the Cherwell REST-API used by gocherwell.
+type Server struct {
+	*httptest.Server
+
+	User     string
+	Password string
+	ClientID string
+	// TokenLifetime is the Lifetime of issued AccessTokens. Default is 20 Minutes.
+	TokenLifetime time.Duration
+	// DefaultPageSize is used for Searches without PageSize. Default is 200.
+	DefaultPageSize int
+
+	mu       sync.Mutex
+	objects  []*busOb
+	tokens   map[string]time.Time
+	refresh  map[string]bool
+	failures []failure
+	requests []Request
+}
+
+// Request is a Request received by the Server.
+type Request struct {
+	Method string
+	Path   string
+	Body   string
+}
+
+// failure is an injected Error Response.
+type failure struct {
+	pathContains string
+	status       int
+	header       http.Header
+}
+
+// busOb is the state of a BusinessObject of the Server.
+type busOb struct {
+	def     BusinessObject
+	records []*record
+	links   map[string]map[string][]string // relationshipID -> parent RecID -> child RecIDs
+}
+
+// record is a stored Record of a BusinessObject.
+type record struct {
+	recID  string
+	values map[string]string // by FieldID
+}
+
+// NewServer starts and returns a Server seeded with the given BusinessObjects.
+// It panics if the BusinessObjects are invalid, like httptest.NewServer does on errors.
+func NewServer(objects ...BusinessObject) *Server {
+	s := &Server{
+		User:            DefaultUser,
+		Password:        DefaultPassword,
+		ClientID:        DefaultClientID,
+		TokenLifetime:   20 * time.Minute,
+		DefaultPageSize: 200,
+		tokens:          make(map[string]time.Time),
+		refresh:         make(map[string]bool),
+	}
+	if err := s.Seed(objects...); err != nil {
+		panic("cherwelltest: " + err.Error())
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// NewServerFromFile starts and returns a Server seeded with the BusinessObjects of a JSON fixture.
+func NewServerFromFile(path string) (*Server, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	objects, err := ReadFixture(f)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+	return NewServer(objects...), nil
+}
+
+// ReadFixture reads a JSON-Array of BusinessObjects.
+func ReadFixture(r io.Reader) ([]BusinessObject, error) {
+	objects := []BusinessObject{}
+	if err := json.NewDecoder(r).Decode(&objects); err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// NewClient returns a gocherwell.Client configured for the Server.
+func (s *Server) NewClient(opts ...gocherwell.Option) *gocherwell.Client {
+	return gocherwell.NewClient(s.User, s.Password, s.ClientID, s.URL+"/", "Internal", "password", opts...)
+}
+
+// Seed adds the given BusinessObjects and their Records to the Server.
+func (s *Server) Seed(objects ...BusinessObject) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, def := range objects {
+		if def.Name == "" {
+			return fmt.Errorf("BusinessObject without Name")
+		}
+		if def.BusObID == "" {
+			def.BusObID = "BO" + def.Name
+		}
+		if def.DisplayName == "" {
+			def.DisplayName = def.Name
+		}
+		if def.Type == "" {
+			def.Type = "Major"
+		}
+		def.Fields = append([]Field{}, def.Fields...)
+		if !hasField(def.Fields, recIDField) {
+			def.Fields = append(def.Fields, Field{Name: recIDField, ReadOnly: true})
+		}
+		for i := range def.Fields {
+			f := &def.Fields[i]
+			if f.FieldID == "" {
+				f.FieldID = "FI" + f.Name
+			}
+			if f.DisplayName == "" {
+				f.DisplayName = f.Name
+			}
+			if f.Type == "" {
+				f.Type = "Text"
+			}
+		}
+		def.Relationships = append([]Relationship{}, def.Relationships...)
+		for i := range def.Relationships {
+			r := &def.Relationships[i]
+			if r.RelationshipID == "" {
+				r.RelationshipID = "RE" + r.Name
+			}
+			if r.DisplayName == "" {
+				r.DisplayName = r.Name
+			}
+			if r.Cardinality == "" {
+				r.Cardinality = "Many"
+			}
+		}
+		def.Searches = append([]Search{}, def.Searches...)
+		for i := range def.Searches {
+			se := &def.Searches[i]
+			if se.SearchID == "" {
+				se.SearchID = "SE" + strings.ReplaceAll(se.Name, " ", "")
+			}
+			if se.Scope == "" {
+				se.Scope = "Global"
+			}
+		}
+		bo := &busOb{def: def, links: make(map[string]map[string][]string)}
+		records := def.Records
+		bo.def.Records = nil
+		s.objects = append(s.objects, bo)
+		for _, values := range records {
+			rec := &record{recID: newID(), values: make(map[string]string)}
+			for _, f := range def.Fields {
+				rec.values[f.FieldID] = f.Default
+			}
+			for name, v := range values {
+				f := bo.field(name)
+				if f == nil {
+					return fmt.Errorf("BusinessObject %v has no Field %v", def.Name, name)
+				}
+				rec.values[f.FieldID] = v
+			}
+			rec.values[bo.field(recIDField).FieldID] = rec.recID
+			bo.records = append(bo.records, rec)
+		}
+		for _, se := range def.Searches {
+			for _, fi := range se.Filters {
+				if bo.field(fi.Field) == nil {
+					return fmt.Errorf("Search %v of BusinessObject %v has no Field %v", se.Name, def.Name, fi.Field)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Records returns the current Records of the BusinessObject with the given Name or BusObID
+// as Values by Field-Name, e.g. to assert the result of a save.
+func (s *Server) Records(busOb string) []map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bo := s.busOb(busOb)
+	if bo == nil {
+		return nil
+	}
+	res := []map[string]string{}
+	for _, rec := range bo.records {
+		values := map[string]string{"RecID": rec.recID}
+		for _, f := range bo.def.Fields {
+			values[f.Name] = rec.values[f.FieldID]
+		}
+		res = append(res, values)
+	}
+	return res
+}
+
+// Linked returns the RecIDs of the Records linked to the given parent Record by the Relationship with the given Name.
+func (s *Server) Linked(busOb, parentRecID, relationship string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bo := s.busOb(busOb)
+	if bo == nil {
+		return nil
+	}
+	rel := bo.relationship(relationship)
+	if rel == nil {
+		return nil
+	}
+	return append([]string{}, bo.links[rel.RelationshipID][parentRecID]...)
+}
+
+// Requests returns all Requests received by the Server.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request{}, s.requests...)
+}
+
+// ExpireTokens invalidates all issued AccessTokens, so the next Request is answered with 401.
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = make(map[string]time.Time)
+}
+
+// FailNext answers the next Request whose Path contains pathContains with the given Status and Headers.
+// An empty pathContains matches every Request.
+func (s *Server) FailNext(pathContains string, status int, header http.Header) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, failure{pathContains: strings.ToLower(pathContains), status: status, header: header})
+}
+
+// busOb returns the BusinessObject with the given BusObID, Name or DisplayName
+func (s *Server) busOb(id string) *busOb {
+	for _, bo := range s.objects {
+		if strings.EqualFold(bo.def.BusObID, id) || bo.def.Name == id || bo.def.DisplayName == id {
+			return bo
+		}
+	}
+	return nil
+}
+
+// field returns the Field with the given FieldID, FullFieldID, Name or DisplayName
+func (bo *busOb) field(id string) *Field {
+	for i, f := range bo.def.Fields {
+		if f.FieldID == id || fullFieldID(bo.def.BusObID, f.FieldID) == id || f.Name == id || f.DisplayName == id {
+			return &bo.def.Fields[i]
+		}
+	}
+	return nil
+}
+
+// hasField reports whether one of the Fields has the given Name
+func hasField(fields []Field, name string) bool {
+	for _, f := range fields {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// relationship returns the Relationship with the given RelationshipID, Name or DisplayName
+func (bo *busOb) relationship(id string) *Relationship {
+	for i, r := range bo.def.Relationships {
+		if r.RelationshipID == id || r.Name == id || r.DisplayName == id {
+			return &bo.def.Relationships[i]
+		}
+	}
+	return nil
+}
+
+// search returns the stored Search with the given SearchID or the Name in the given Scope
+func (bo *busOb) search(searchID, name, scope string) *Search {
+	for i, se := range bo.def.Searches {
+		if searchID != "" && se.SearchID == searchID {
+			return &bo.def.Searches[i]
+		}
+		if searchID == "" && se.Name == name && (scope == "" || strings.EqualFold(se.Scope, scope)) {
+			return &bo.def.Searches[i]
+		}
+	}
+	return nil
+}
+
+// record returns the Record with the given RecID or PublicID
+func (bo *busOb) record(recID, publicID string) *record {
+	for _, rec := range bo.records {
+		if recID != "" && rec.recID == recID {
+			return rec
+		}
+		if publicID != "" && bo.publicID(rec) == publicID {
+			return rec
+		}
+	}
+	return nil
+}
+
+// publicID returns the PublicID of a Record
+func (bo *busOb) publicID(rec *record) string {
+	if bo.def.PublicIDField != "" {
+		if f := bo.field(bo.def.PublicIDField); f != nil {
+			return rec.values[f.FieldID]
+		}
+	}
+	return rec.recID
+}
+
+// fullFieldID returns the FullFieldID of a Field as used by Cherwell
+func fullFieldID(busObID, fieldID string) string {
+	return "BO:" + busObID + ",FI:" + fieldID
+}
+
+// idSeq numbers the IDs returned by newID.
+var idSeq uint64
+
+// newID returns an ID like the RecIDs of Cherwell. IDs increase in the Order they are created,
+// so Records without Sorting are returned in the Order of their RecIDs, as by Cherwell.
+func newID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return fmt.Sprintf("%010x", atomic.AddUint64(&idSeq, 1)) + hex.EncodeToString(b)
+}
@@ -0,0 +1,263 @@
+package cherwelltest_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/itsscb/gocherwell"
+	"github.com/itsscb/gocherwell/cherwelltest"
+)
+
+// computer is a BusinessObject with a Record per AssetName.
+func computer(names ...string) cherwelltest.BusinessObject {
+	records := []map[string]string{}
+	for _, name := range names {
+		records = append(records, map[string]string{"AssetName": name})
+	}
+	return cherwelltest.BusinessObject{
+		Name:    "ConfigComputer",
+		Fields:  []cherwelltest.Field{{Name: "AssetName", Required: true}, {Name: "Status", Default: "New"}, {Name: "Serial", ReadOnly: true}},
+		Records: records,
+	}
+}
+
+func TestSeed(t *testing.T) {
+	tests := []struct {
+		name    string
+		busOb   cherwelltest.BusinessObject
+		wantErr string
+	}{
+		{"valid", computer("NB001"), ""},
+		{"without name", cherwelltest.BusinessObject{}, "BusinessObject without Name"},
+		{"record of unknown field", cherwelltest.BusinessObject{Name: "X", Records: []map[string]string{{"Missing": "x"}}}, "BusinessObject X has no Field Missing"},
+		{"search of unknown field", cherwelltest.BusinessObject{Name: "X", Searches: []cherwelltest.Search{{Name: "S", Filters: []cherwelltest.Filter{{Field: "Missing"}}}}}, "Search S of BusinessObject X has no Field Missing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := cherwelltest.NewServer()
+			defer srv.Close()
+			err := srv.Seed(tt.busOb)
+			if (err == nil) != (tt.wantErr == "") || err != nil && err.Error() != tt.wantErr {
+				t.Errorf("Seed() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSeedDefaults(t *testing.T) {
+	srv := cherwelltest.NewServer(computer("NB001"))
+	defer srv.Close()
+	ctx := context.Background()
+	cl := srv.NewClient()
+	bo, err := cl.ResolveBusinessObject(ctx, "ConfigComputer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bo.BusObID != "BOConfigComputer" || bo.DisplayName != "ConfigComputer" {
+		t.Errorf("BusinessObject = %+v, want BusObID and DisplayName derived from the Name", bo)
+	}
+	sch, err := bo.GetBusinessObjectSchema(ctx, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	def, err := sch.FieldDefinition("FIStatus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if def.Type != "Text" || def.DisplayName != "Status" {
+		t.Errorf("FieldDefinition = %+v, want Type and DisplayName derived", def)
+	}
+	if got := srv.Records("ConfigComputer")[0]["Status"]; got != "New" {
+		t.Errorf("Status = %q, want the Default New", got)
+	}
+}
+
+func TestSave(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]interface{}
+		status int
+		want   string
+	}{
+		{"update", map[string]interface{}{"Status": "Active"}, 0, "Active"},
+		{"read only", map[string]interface{}{"Serial": "S1"}, http.StatusBadRequest, "New"},
+		{"required", map[string]interface{}{"AssetName": ""}, http.StatusBadRequest, "New"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := cherwelltest.NewServer(computer("NB001"))
+			defer srv.Close()
+			ctx := context.Background()
+			cl := srv.NewClient()
+			bo, err := cl.ResolveBusinessObject(ctx, "ConfigComputer")
+			if err != nil {
+				t.Fatal(err)
+			}
+			rec, err := bo.NewQuery().First(ctx, cl)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for k, v := range tt.values {
+				rec.FieldValues[k] = v
+			}
+			_, err = rec.SaveBusinessObjectRecord(ctx, cl)
+			var apiErr *gocherwell.APIError
+			switch {
+			case tt.status == 0 && err != nil:
+				t.Fatalf("SaveBusinessObjectRecord() error = %v", err)
+			case tt.status != 0 && (!errors.As(err, &apiErr) || apiErr.StatusCode != tt.status || len(apiErr.FieldValidationErrors) != 1):
+				t.Fatalf("SaveBusinessObjectRecord() error = %v, want Status %v with a FieldValidationError", err, tt.status)
+			}
+			if got := srv.Records("ConfigComputer")[0]["Status"]; got != tt.want {
+				t.Errorf("Status = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFaults(t *testing.T) {
+	tests := []struct {
+		name   string
+		inject func(srv *cherwelltest.Server)
+		status int
+	}{
+		{"no fault", func(srv *cherwelltest.Server) {}, 0},
+		{"fail next", func(srv *cherwelltest.Server) { srv.FailNext("getbusinessobjectschema", http.StatusConflict, nil) }, http.StatusConflict},
+		{"fail other path", func(srv *cherwelltest.Server) { srv.FailNext("savebusinessobject", http.StatusConflict, nil) }, 0},
+		{"expired tokens", func(srv *cherwelltest.Server) { srv.ExpireTokens() }, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := cherwelltest.NewServer(computer("NB001"))
+			defer srv.Close()
+			ctx := context.Background()
+			// A Token without RefreshToken can not be renewed, so the Status of the Server is returned.
+			cl, err := srv.NewClient().Login(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cl = srv.NewClient(
+				gocherwell.WithTokenSource(gocherwell.StaticTokenSource(&gocherwell.Token{AccessToken: cl.Token().AccessToken})),
+				gocherwell.WithRetryPolicy(gocherwell.RetryPolicy{MaxAttempts: 1}),
+			)
+			tt.inject(srv)
+			_, err = cl.GetBusinessObjectSchema(ctx, &gocherwell.BusinessObject{BusObID: "BOConfigComputer"})
+			var apiErr *gocherwell.APIError
+			switch {
+			case tt.status == 0 && err != nil:
+				t.Errorf("error = %v, want nil", err)
+			case tt.status != 0 && (!errors.As(err, &apiErr) || apiErr.StatusCode != tt.status):
+				t.Errorf("error = %v, want Status %v", err, tt.status)
+			}
+		})
+	}
+}
+
+func TestToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		user     string
+		password string
+		clientID string
+		err      error
+	}{
+		{"valid", cherwelltest.DefaultUser, cherwelltest.DefaultPassword, cherwelltest.DefaultClientID, nil},
+		{"wrong password", cherwelltest.DefaultUser, "wrong", cherwelltest.DefaultClientID, gocherwell.ErrUnauthorized},
+		{"wrong client", cherwelltest.DefaultUser, cherwelltest.DefaultPassword, "wrong", gocherwell.ErrUnauthorized},
+	}
+	srv := cherwelltest.NewServer()
+	defer srv.Close()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl := gocherwell.NewClient(tt.user, tt.password, tt.clientID, srv.URL+"/", "Internal", "password")
+			_, err := cl.Login(context.Background())
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Login() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestPaging(t *testing.T) {
+	names := []string{}
+	for i := 0; i < 25; i++ {
+		names = append(names, string(rune('A'+i)))
+	}
+	srv := cherwelltest.NewServer(computer(names...))
+	defer srv.Close()
+	srv.DefaultPageSize = 10
+	ctx := context.Background()
+	cl := srv.NewClient()
+	bo, err := cl.ResolveBusinessObject(ctx, "ConfigComputer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	it := bo.NewQuery().PageSize(10).Iterator(ctx, cl)
+	defer it.Close()
+	got := []string{}
+	for it.Next() {
+		got = append(got, it.Record().FieldValues["AssetName"].(string))
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(got, "") != strings.Join(names, "") {
+		t.Errorf("Records = %v, want %v in the Order they were created", got, names)
+	}
+	if it.TotalRows() != 25 {
+		t.Errorf("TotalRows() = %v, want 25", it.TotalRows())
+	}
+}
+
+func TestNewServerFromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cherwelltest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	tests := []struct {
+		name    string
+		content string
+		records int
+		wantErr bool
+	}{
+		{"valid", `[{"name":"ConfigComputer","fields":[{"name":"AssetName"}],"records":[{"AssetName":"NB001"},{"AssetName":"NB002"}]}]`, 2, false},
+		{"invalid json", `{`, 0, true},
+		{"invalid business object", `[{"name":""}]`, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, strings.ReplaceAll(tt.name, " ", "_")+".json")
+			if err := ioutil.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			srv, err := newServerFromFile(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewServerFromFile() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer srv.Close()
+			if got := len(srv.Records("ConfigComputer")); got != tt.records {
+				t.Errorf("Records = %v, want %v", got, tt.records)
+			}
+		})
+	}
+}
+
+// newServerFromFile calls NewServerFromFile and returns a panic of NewServer as error
+func newServerFromFile(path string) (srv *cherwelltest.Server, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New(r.(string))
+		}
+	}()
+	return cherwelltest.NewServerFromFile(path)
+}
@@ -0,0 +1,1010 @@
+package cherwelltest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// apiError is the Error part of the Responses of the Cherwell API.
+type apiError struct {
+	ErrorCode      string `json:"errorCode,omitempty"`
+	ErrorMessage   string `json:"errorMessage,omitempty"`
+	HasError       bool   `json:"hasError"`
+	HTTPStatusCode string `json:"httpStatusCode,omitempty"`
+}
+
+// fieldJSON is a Field of a Record or Template.
+type fieldJSON struct {
+	Dirty       bool   `json:"dirty"`
+	DisplayName string `json:"displayName"`
+	FieldID     string `json:"fieldId"`
+	FullFieldID string `json:"fullFieldId"`
+	HTML        string `json:"html,omitempty"`
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+}
+
+// recordJSON is a Record as returned by getbusinessobject and getsearchresults.
+type recordJSON struct {
+	BusObID       string      `json:"busObId"`
+	BusObPublicID string      `json:"busObPublicId"`
+	BusObRecID    string      `json:"busObRecId"`
+	Fields        []fieldJSON `json:"fields"`
+	Links         []struct{}  `json:"links"`
+	apiError
+}
+
+// fieldValidationError is an Entry of the fieldValidationErrors of savebusinessobject.
+type fieldValidationError struct {
+	Error     string `json:"error"`
+	ErrorCode string `json:"errorCode"`
+	FieldID   string `json:"fieldId"`
+}
+
+// serveHTTP routes all Requests of the Server
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	segs := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	route := strings.ToLower(strings.Join(segs, "/"))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.RequestURI(), Body: string(body)})
+
+	for i, f := range s.failures {
+		if strings.Contains(route, f.pathContains) {
+			s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			for k, v := range f.header {
+				w.Header()[k] = v
+			}
+			writeError(w, f.status, "InjectedFailure", http.StatusText(f.status))
+			return
+		}
+	}
+
+	if route == "token" {
+		s.handleToken(w, r, body)
+		return
+	}
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "Unauthorized", "Authorization has been denied for this request.")
+		return
+	}
+
+	if len(segs) < 3 || strings.ToLower(segs[0]) != "api" {
+		writeError(w, http.StatusNotFound, "NotFound", "No route for "+r.URL.Path)
+		return
+	}
+	action := strings.ToLower(segs[2])
+	p := params(segs[3:])
+	switch {
+	case action == "logout" && r.Method == http.MethodDelete:
+		s.handleLogout(w, r)
+	case action == "getbusinessobjectsummaries" && r.Method == http.MethodGet:
+		s.handleSummaries(w, p["type"])
+	case action == "getbusinessobjectsummary" && r.Method == http.MethodGet:
+		s.handleSummary(w, p["busobid"], p["busobname"])
+	case action == "getbusinessobjectschema" && r.Method == http.MethodGet:
+		s.handleSchema(w, p["busobid"], r.URL.Query().Get("includerelationships") == "true")
+	case action == "getbusinessobjecttemplate" && r.Method == http.MethodPost:
+		s.handleTemplate(w, body)
+	case action == "getquicksearchresults" && r.Method == http.MethodPost:
+		s.handleQuickSearch(w, body)
+	case action == "getsearchitems" && r.Method == http.MethodGet:
+		s.handleSearchItems(w, p["association"])
+	case action == "getsearchresults" && r.Method == http.MethodPost:
+		s.handleSearch(w, body)
+	case action == "getbusinessobject" && r.Method == http.MethodGet:
+		s.handleGetRecord(w, p["busobid"], p["busobrecid"], p["publicid"])
+	case action == "savebusinessobject" && r.Method == http.MethodPost:
+		s.handleSave(w, body)
+	case action == "deletebusinessobject" && r.Method == http.MethodDelete:
+		s.handleDelete(w, p["busobid"], p["busobrecid"])
+	case action == "getrelatedbusinessobject" && r.Method == http.MethodGet:
+		s.handleRelated(w, p["parentbusobid"], p["parentbusobrecid"], p["relationshipid"])
+	case action == "linkrelatedbusinessobject" && r.Method == http.MethodGet:
+		s.handleLink(w, p, true)
+	case action == "unlinkrelatedbusinessobject" && r.Method == http.MethodDelete:
+		s.handleLink(w, p, false)
+	default:
+		writeError(w, http.StatusNotFound, "NotFound", "No route for "+r.Method+" "+r.URL.Path)
+	}
+}
+
+// params converts alternating Names and Values of Path-Segments to a map with lowercase Names
+func params(segs []string) map[string]string {
+	p := make(map[string]string)
+	for i := 0; i+1 < len(segs); i += 2 {
+		v, err := url.PathUnescape(segs[i+1])
+		if err != nil {
+			v = segs[i+1]
+		}
+		p[strings.ToLower(segs[i])] = v
+	}
+	return p
+}
+
+// authorized checks the Bearer-Token of the Request
+func (s *Server) authorized(r *http.Request) bool {
+	h := r.Header.Get("Authorization")
+	if !strings.HasPrefix(strings.ToLower(h), "bearer ") {
+		return false
+	}
+	exp, ok := s.tokens[strings.TrimSpace(h[len("bearer "):])]
+	return ok && time.Now().Before(exp)
+}
+
+// handleToken issues Tokens for the password and refresh_token Grants
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request, body []byte) {
+	form, _ := url.ParseQuery(string(body))
+	tokenError := func(code, desc string) {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": code, "error_description": desc})
+	}
+	if form.Get("client_id") != s.ClientID {
+		tokenError("invalid_client", "Client ID is invalid.")
+		return
+	}
+	switch form.Get("grant_type") {
+	case "password":
+		if form.Get("username") != s.User || form.Get("password") != s.Password {
+			tokenError("invalid_grant", "BADREQUEST: The user name or password is incorrect.")
+			return
+		}
+	case "refresh_token":
+		rt := form.Get("refresh_token")
+		if !s.refresh[rt] {
+			tokenError("invalid_grant", "Refresh token is invalid.")
+			return
+		}
+		delete(s.refresh, rt)
+	default:
+		tokenError("unsupported_grant_type", "Grant type is not supported.")
+		return
+	}
+
+	now := time.Now().UTC()
+	lifetime := s.TokenLifetime
+	if lifetime <= 0 {
+		lifetime = 20 * time.Minute
+	}
+	access, refresh := newID(), newID()
+	s.tokens[access] = now.Add(lifetime)
+	s.refresh[refresh] = true
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token":  access,
+		"token_type":    "bearer",
+		"expires_in":    int(lifetime.Seconds()),
+		"refresh_token": refresh,
+		"as:client_id":  s.ClientID,
+		"username":      s.User,
+		".issued":       now.Format(time.RFC1123),
+		".expires":      now.Add(lifetime).Format(time.RFC1123),
+	})
+}
+
+// handleLogout revokes the AccessToken of the Request
+func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request) {
+	h := r.Header.Get("Authorization")
+	delete(s.tokens, strings.TrimSpace(h[len("bearer "):]))
+	writeJSON(w, http.StatusOK, apiError{})
+}
+
+// summary returns the Summary of a BusinessObject as returned by getbusinessobjectsummaries
+func (s *Server) summary(bo *busOb) map[string]interface{} {
+	res := map[string]interface{}{
+		"busObId":     bo.def.BusObID,
+		"name":        bo.def.Name,
+		"displayName": bo.def.DisplayName,
+		"major":       bo.def.Type == "Major" || bo.def.Type == "Group",
+		"supporting":  bo.def.Type == "Supporting",
+		"lookup":      bo.def.Type == "Lookup",
+		"group":       bo.def.Type == "Group",
+		"stateFieldId": func() string {
+			if f := bo.field(bo.def.StateField); f != nil {
+				return f.FieldID
+			}
+			return ""
+		}(),
+		"states":          strings.Join(bo.def.States, ","),
+		"firstRecIdField": bo.field(recIDField).FieldID,
+		"recIdFields":     bo.field(recIDField).FieldID,
+	}
+	if bo.def.Type == "Group" {
+		members := []map[string]interface{}{}
+		for _, m := range s.objects {
+			if m.def.Group == bo.def.BusObID || (m.def.Group != "" && m.def.Group == bo.def.Name) {
+				members = append(members, s.summary(m))
+			}
+		}
+		res["groupSummaries"] = members
+	}
+	return res
+}
+
+// handleSummaries returns the Summaries of all BusinessObjects of the given Type
+func (s *Server) handleSummaries(w http.ResponseWriter, typ string) {
+	res := []map[string]interface{}{}
+	for _, bo := range s.objects {
+		if bo.def.Group != "" {
+			continue
+		}
+		switch strings.ToLower(typ) {
+		case "all":
+		case "groups":
+			if bo.def.Type != "Group" {
+				continue
+			}
+		case "major":
+			if bo.def.Type != "Major" && bo.def.Type != "Group" {
+				continue
+			}
+		default:
+			if !strings.EqualFold(bo.def.Type, typ) {
+				continue
+			}
+		}
+		res = append(res, s.summary(bo))
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+// handleSummary returns the Summary of a single BusinessObject by BusObID or Name
+func (s *Server) handleSummary(w http.ResponseWriter, busObID, name string) {
+	for _, bo := range s.objects {
+		if (busObID != "" && strings.EqualFold(bo.def.BusObID, busObID)) || (name != "" && strings.EqualFold(bo.def.Name, name)) {
+			writeJSON(w, http.StatusOK, []map[string]interface{}{s.summary(bo)})
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, []map[string]interface{}{})
+}
+
+// fieldDefinitions returns the FieldDefinitions of a BusinessObject as returned by getbusinessobjectschema
+func fieldDefinitions(bo *busOb) []map[string]interface{} {
+	res := []map[string]interface{}{}
+	for _, f := range bo.def.Fields {
+		res = append(res, map[string]interface{}{
+			"fieldId":       f.FieldID,
+			"name":          f.Name,
+			"displayName":   f.DisplayName,
+			"type":          f.Type,
+			"typeLocalized": f.Type,
+			"required":      f.Required,
+			"readOnly":      f.ReadOnly,
+			"hasDate":       f.HasDate,
+			"hasTime":       f.HasTime,
+			"decimalDigits": f.DecimalDigits,
+			"wholeDigits":   f.WholeDigits,
+			"enabled":       true,
+			"maximumSize":   "",
+		})
+	}
+	return res
+}
+
+// handleSchema returns the Schema of a BusinessObject
+func (s *Server) handleSchema(w http.ResponseWriter, busObID string, includeRelationships bool) {
+	bo := s.busOb(busObID)
+	if bo == nil {
+		writeError(w, http.StatusNotFound, "BUSINESSOBJECTNOTFOUND", "BusinessObject "+busObID+" not found")
+		return
+	}
+	res := s.summary(bo)
+	delete(res, "groupSummaries")
+	res["fieldDefinitions"] = fieldDefinitions(bo)
+	res["gridDefinitions"] = []struct{}{}
+	rels := []map[string]interface{}{}
+	if includeRelationships {
+		for _, r := range bo.def.Relationships {
+			rel := map[string]interface{}{
+				"relationshipId": r.RelationshipID,
+				"displayName":    r.DisplayName,
+				"description":    r.Name,
+				"cardinality":    r.Cardinality,
+				"target":         r.Target,
+			}
+			if t := s.busOb(r.Target); t != nil {
+				rel["target"] = t.def.BusObID
+				rel["fieldDefinitions"] = fieldDefinitions(t)
+			}
+			rels = append(rels, rel)
+		}
+	}
+	res["relationships"] = rels
+	writeJSON(w, http.StatusOK, res)
+}
+
+// handleTemplate returns the Template of a BusinessObject with the Default Values of all Fields
+func (s *Server) handleTemplate(w http.ResponseWriter, body []byte) {
+	req := struct {
+		BusObID string `json:"busObId"`
+	}{}
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+	bo := s.busOb(req.BusObID)
+	if bo == nil {
+		writeError(w, http.StatusNotFound, "BUSINESSOBJECTNOTFOUND", "BusinessObject "+req.BusObID+" not found")
+		return
+	}
+	fields := []fieldJSON{}
+	for _, f := range bo.def.Fields {
+		fields = append(fields, fieldJSON{
+			DisplayName: f.DisplayName,
+			FieldID:     f.FieldID,
+			FullFieldID: fullFieldID(bo.def.BusObID, f.FieldID),
+			Name:        f.Name,
+			Value:       f.Default,
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"fields": fields})
+}
+
+// recordJSON converts a Record to its JSON representation including the given Fields or all if none are given
+func (bo *busOb) recordJSON(rec *record, fieldIDs []string) recordJSON {
+	res := recordJSON{
+		BusObID:       bo.def.BusObID,
+		BusObPublicID: bo.publicID(rec),
+		BusObRecID:    rec.recID,
+		Fields:        []fieldJSON{},
+		Links:         []struct{}{},
+	}
+	for _, f := range bo.def.Fields {
+		if len(fieldIDs) > 0 && !contains(fieldIDs, f.FieldID) && !contains(fieldIDs, fullFieldID(bo.def.BusObID, f.FieldID)) {
+			continue
+		}
+		res.Fields = append(res.Fields, fieldJSON{
+			DisplayName: f.DisplayName,
+			FieldID:     f.FieldID,
+			FullFieldID: fullFieldID(bo.def.BusObID, f.FieldID),
+			Name:        f.Name,
+			Value:       rec.values[f.FieldID],
+		})
+	}
+	return res
+}
+
+// filterJSON is a Filter of getsearchresults.
+type filterJSON struct {
+	FieldID  string `json:"fieldId"`
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+}
+
+// promptJSON is a Prompt of a stored Search.
+type promptJSON struct {
+	BusObID        string `json:"busObId"`
+	Default        string `json:"default,omitempty"`
+	FieldID        string `json:"fieldId"`
+	PromptID       string `json:"promptId"`
+	PromptType     string `json:"promptType"`
+	PromptTypeName string `json:"promptTypeName"`
+	Required       bool   `json:"required"`
+	Text           string `json:"text"`
+}
+
+// searchRequest is the Body of getsearchresults.
+type searchRequest struct {
+	Association  string       `json:"association"`
+	BusObID      string       `json:"busObId"`
+	Filters      []filterJSON `json:"filters"`
+	PromptValues []struct {
+		PromptID string      `json:"promptId"`
+		Value    interface{} `json:"value"`
+	} `json:"promptValues"`
+	Scope            string   `json:"scope"`
+	SearchID         string   `json:"searchId"`
+	SearchName       string   `json:"searchName"`
+	Fields           []string `json:"fields"`
+	IncludeAllFields bool     `json:"includeAllFields"`
+	PageNumber       int      `json:"pageNumber"`
+	PageSize         int      `json:"pageSize"`
+	Sorting          []struct {
+		FieldID       string `json:"fieldId"`
+		SortDirection int    `json:"sortDirection"`
+	} `json:"sorting"`
+}
+
+// handleSearch filters, sorts and pages the Records of a BusinessObject. Filters on the same Field
+// are combined with OR, Filters on different Fields with AND.
+func (s *Server) handleSearch(w http.ResponseWriter, body []byte) {
+	req := searchRequest{}
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+	if req.BusObID == "" {
+		req.BusObID = req.Association
+	}
+	bo := s.busOb(req.BusObID)
+	if bo == nil {
+		writeError(w, http.StatusNotFound, "BUSINESSOBJECTNOTFOUND", "BusinessObject "+req.BusObID+" not found")
+		return
+	}
+	if req.SearchID != "" || req.SearchName != "" {
+		se := bo.search(req.SearchID, req.SearchName, req.Scope)
+		if se == nil {
+			writeError(w, http.StatusNotFound, "SEARCHNOTFOUND", "Search "+req.SearchID+req.SearchName+" not found")
+			return
+		}
+		values := make(map[string]string)
+		for _, pv := range req.PromptValues {
+			values[pv.PromptID] = fmt.Sprint(pv.Value)
+		}
+		prompts := []promptJSON{}
+		missing := false
+		for i, fi := range se.Filters {
+			field := bo.field(fi.Field)
+			value := fi.Value
+			if fi.Prompt != "" {
+				id := fmt.Sprintf("PR%v%d", se.SearchID, i)
+				prompts = append(prompts, promptJSON{
+					BusObID:        bo.def.BusObID,
+					Default:        fi.Value,
+					FieldID:        field.FieldID,
+					PromptID:       id,
+					PromptType:     field.Type,
+					PromptTypeName: field.Type,
+					Required:       fi.Value == "",
+					Text:           fi.Prompt,
+				})
+				if v, ok := values[id]; ok {
+					value = v
+				} else if fi.Value == "" {
+					missing = true
+				}
+			}
+			req.Filters = append(req.Filters, filterJSON{FieldID: field.FieldID, Operator: fi.Operator, Value: value})
+		}
+		if missing || (len(prompts) > 0 && len(req.PromptValues) == 0) {
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"businessObjects": []recordJSON{},
+				"totalRows":       0,
+				"hasPrompts":      true,
+				"prompts":         prompts,
+				"links":           []struct{}{},
+			})
+			return
+		}
+	}
+
+	type condition struct{ op, value string }
+	byField := map[string][]condition{}
+	order := []string{}
+	for _, f := range req.Filters {
+		field := bo.field(f.FieldID)
+		if field == nil {
+			writeError(w, http.StatusBadRequest, "FIELDNOTFOUND", "Field "+f.FieldID+" not found")
+			return
+		}
+		op := strings.ToLower(f.Operator)
+		switch op {
+		case "eq", "gt", "lt", "contains", "startswith":
+		default:
+			writeError(w, http.StatusBadRequest, "INVALIDOPERATOR", "Operator "+f.Operator+" is not supported")
+			return
+		}
+		if _, ok := byField[field.FieldID]; !ok {
+			order = append(order, field.FieldID)
+		}
+		byField[field.FieldID] = append(byField[field.FieldID], condition{op, f.Value})
+	}
+
+	matches := []*record{}
+	for _, rec := range bo.records {
+		ok := true
+		for _, fieldID := range order {
+			matched := false
+			for _, c := range byField[fieldID] {
+				if match(rec.values[fieldID], c.op, c.value) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			matches = append(matches, rec)
+		}
+	}
+
+	for i := len(req.Sorting) - 1; i >= 0; i-- {
+		field := bo.field(req.Sorting[i].FieldID)
+		if field == nil {
+			writeError(w, http.StatusBadRequest, "FIELDNOTFOUND", "Field "+req.Sorting[i].FieldID+" not found")
+			return
+		}
+		desc := req.Sorting[i].SortDirection < 0
+		sort.SliceStable(matches, func(a, b int) bool {
+			c := compare(matches[a].values[field.FieldID], matches[b].values[field.FieldID])
+			if desc {
+				return c > 0
+			}
+			return c < 0
+		})
+	}
+
+	total := len(matches)
+	size := req.PageSize
+	if size <= 0 {
+		size = s.DefaultPageSize
+	}
+	page := req.PageNumber
+	if page <= 0 {
+		page = 1
+	}
+	if size > 0 {
+		from := (page - 1) * size
+		if from > len(matches) {
+			from = len(matches)
+		}
+		to := from + size
+		if to > len(matches) {
+			to = len(matches)
+		}
+		matches = matches[from:to]
+	}
+
+	fields := req.Fields
+	if req.IncludeAllFields {
+		fields = nil
+	}
+	res := []recordJSON{}
+	for _, rec := range matches {
+		res = append(res, bo.recordJSON(rec, fields))
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"businessObjects": res,
+		"totalRows":       total,
+		"hasPrompts":      false,
+		"links":           []struct{}{},
+	})
+}
+
+// handleQuickSearch returns the Records with a Text-Field containing the searchText, grouped by BusinessObject.
+// Without busObIds all BusinessObjects are searched.
+func (s *Server) handleQuickSearch(w http.ResponseWriter, body []byte) {
+	req := struct {
+		BusObIDs   []string `json:"busObIds"`
+		SearchText string   `json:"searchText"`
+	}{}
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+	objects := s.objects
+	if len(req.BusObIDs) > 0 {
+		objects = []*busOb{}
+		for _, id := range req.BusObIDs {
+			bo := s.busOb(id)
+			if bo == nil {
+				writeError(w, http.StatusNotFound, "BUSINESSOBJECTNOTFOUND", "BusinessObject "+id+" not found")
+				return
+			}
+			objects = append(objects, bo)
+		}
+	}
+	type item struct {
+		BusObID    string `json:"busObId"`
+		BusObRecID string `json:"busObRecId"`
+		PublicID   string `json:"publicId"`
+		SubTitle   string `json:"subTitle"`
+		Text       string `json:"text"`
+		Title      string `json:"title"`
+	}
+	type group struct {
+		IsBusObTarget          bool   `json:"isBusObTarget"`
+		SimpleResultsListItems []item `json:"simpleResultsListItems"`
+		SubTitle               string `json:"subTitle"`
+		TargetID               string `json:"targetId"`
+		Title                  string `json:"title"`
+	}
+	text := strings.ToLower(req.SearchText)
+	groups := []group{}
+	for _, bo := range objects {
+		g := group{IsBusObTarget: true, SimpleResultsListItems: []item{}, TargetID: bo.def.BusObID, Title: bo.def.DisplayName}
+		for _, rec := range bo.records {
+			for _, f := range bo.def.Fields {
+				v := rec.values[f.FieldID]
+				if f.Type == "Text" && text != "" && strings.Contains(strings.ToLower(v), text) {
+					g.SimpleResultsListItems = append(g.SimpleResultsListItems, item{
+						BusObID:    bo.def.BusObID,
+						BusObRecID: rec.recID,
+						PublicID:   bo.publicID(rec),
+						SubTitle:   bo.def.DisplayName,
+						Text:       v,
+						Title:      bo.publicID(rec),
+					})
+					break
+				}
+			}
+		}
+		if len(g.SimpleResultsListItems) > 0 {
+			g.SubTitle = strconv.Itoa(len(g.SimpleResultsListItems)) + " Results"
+			groups = append(groups, g)
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"groups": groups,
+		"title":  "Quick Search: " + req.SearchText,
+	})
+}
+
+// handleSearchItems returns the Tree of stored Searches of all BusinessObjects or the given one,
+// with a Folder per Scope and ScopeOwner
+func (s *Server) handleSearchItems(w http.ResponseWriter, association string) {
+	type item struct {
+		Association    string `json:"association"`
+		DisplayName    string `json:"displayName"`
+		ID             string `json:"id"`
+		Name           string `json:"name"`
+		ParentFolderID string `json:"parentFolderId"`
+		Scope          string `json:"scope"`
+		ScopeOwner     string `json:"scopeOwner"`
+	}
+	type folder struct {
+		Association    string   `json:"association,omitempty"`
+		ChildFolders   []folder `json:"childFolders"`
+		ChildItems     []item   `json:"childItems"`
+		ID             string   `json:"id"`
+		Name           string   `json:"name"`
+		ParentFolderID string   `json:"parentFolderId,omitempty"`
+		Scope          string   `json:"scope,omitempty"`
+		ScopeOwner     string   `json:"scopeOwner,omitempty"`
+	}
+	objects := s.objects
+	if association != "" {
+		bo := s.busOb(association)
+		if bo == nil {
+			writeError(w, http.StatusNotFound, "BUSINESSOBJECTNOTFOUND", "BusinessObject "+association+" not found")
+			return
+		}
+		objects = []*busOb{bo}
+	}
+	root := folder{ChildFolders: []folder{}, ChildItems: []item{}, ID: "root", Name: "Searches"}
+	index := make(map[string]int)
+	for _, bo := range objects {
+		for _, se := range bo.def.Searches {
+			key := strings.ToLower(se.Scope + "/" + se.ScopeOwner)
+			i, ok := index[key]
+			if !ok {
+				i = len(root.ChildFolders)
+				index[key] = i
+				name := se.Scope
+				if se.ScopeOwner != "" {
+					name += " (" + se.ScopeOwner + ")"
+				}
+				root.ChildFolders = append(root.ChildFolders, folder{
+					Association:    association,
+					ChildFolders:   []folder{},
+					ChildItems:     []item{},
+					ID:             "FO" + strings.ReplaceAll(key, "/", ""),
+					Name:           name,
+					ParentFolderID: root.ID,
+					Scope:          se.Scope,
+					ScopeOwner:     se.ScopeOwner,
+				})
+			}
+			f := &root.ChildFolders[i]
+			f.ChildItems = append(f.ChildItems, item{
+				Association:    bo.def.BusObID,
+				DisplayName:    se.Name,
+				ID:             se.SearchID,
+				Name:           se.Name,
+				ParentFolderID: f.ID,
+				Scope:          se.Scope,
+				ScopeOwner:     se.ScopeOwner,
+			})
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"root": root})
+}
+
+// handleGetRecord returns a Record by RecID or PublicID
+func (s *Server) handleGetRecord(w http.ResponseWriter, busObID, recID, publicID string) {
+	bo := s.busOb(busObID)
+	if bo == nil {
+		writeError(w, http.StatusNotFound, "BUSINESSOBJECTNOTFOUND", "BusinessObject "+busObID+" not found")
+		return
+	}
+	rec := bo.record(recID, publicID)
+	if rec == nil {
+		writeError(w, http.StatusNotFound, "RECORDNOTFOUND", "Record not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, bo.recordJSON(rec, nil))
+}
+
+// handleSave creates or updates a Record and enforces required and readOnly Fields
+func (s *Server) handleSave(w http.ResponseWriter, body []byte) {
+	req := struct {
+		BusObID       string      `json:"busObId"`
+		BusObRecID    string      `json:"busObRecId"`
+		BusObPublicID string      `json:"busObPublicId"`
+		Fields        []fieldJSON `json:"fields"`
+	}{}
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+	bo := s.busOb(req.BusObID)
+	if bo == nil {
+		writeError(w, http.StatusNotFound, "BUSINESSOBJECTNOTFOUND", "BusinessObject "+req.BusObID+" not found")
+		return
+	}
+
+	rec := &record{values: make(map[string]string)}
+	create := req.BusObRecID == "" && req.BusObPublicID == ""
+	if create {
+		for _, f := range bo.def.Fields {
+			rec.values[f.FieldID] = f.Default
+		}
+	} else {
+		existing := bo.record(req.BusObRecID, req.BusObPublicID)
+		if existing == nil {
+			writeError(w, http.StatusNotFound, "RECORDNOTFOUND", "Record not found")
+			return
+		}
+		rec.recID = existing.recID
+		for k, v := range existing.values {
+			rec.values[k] = v
+		}
+	}
+
+	errs := []fieldValidationError{}
+	for _, fj := range req.Fields {
+		if !fj.Dirty {
+			continue
+		}
+		id := fj.FieldID
+		if id == "" {
+			id = fj.Name
+		}
+		f := bo.field(id)
+		if f == nil {
+			errs = append(errs, fieldValidationError{Error: "Field not found", ErrorCode: "FIELDNOTFOUND", FieldID: id})
+			continue
+		}
+		if f.ReadOnly {
+			errs = append(errs, fieldValidationError{Error: f.DisplayName + " is read-only", ErrorCode: "READONLY", FieldID: f.FieldID})
+			continue
+		}
+		rec.values[f.FieldID] = fj.Value
+	}
+	if create && bo.def.PublicIDField != "" {
+		if f := bo.field(bo.def.PublicIDField); f != nil && rec.values[f.FieldID] == "" {
+			rec.values[f.FieldID] = strconv.Itoa(len(bo.records) + 1)
+		}
+	}
+	for _, f := range bo.def.Fields {
+		if f.Required && strings.TrimSpace(rec.values[f.FieldID]) == "" {
+			errs = append(errs, fieldValidationError{Error: f.DisplayName + " is required", ErrorCode: "REQUIRED", FieldID: f.FieldID})
+		}
+	}
+	if len(errs) > 0 {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"busObId":               bo.def.BusObID,
+			"fieldValidationErrors": errs,
+			"hasError":              true,
+			"errorCode":             "ValidationError",
+			"errorMessage":          fmt.Sprintf("%d Field(s) failed validation", len(errs)),
+			"httpStatusCode":        "BadRequest",
+		})
+		return
+	}
+
+	if create {
+		rec.recID = newID()
+		rec.values[bo.field(recIDField).FieldID] = rec.recID
+		bo.records = append(bo.records, rec)
+	} else {
+		bo.record(rec.recID, "").values = rec.values
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"busObId":       bo.def.BusObID,
+		"busObRecId":    rec.recID,
+		"busObPublicId": bo.publicID(rec),
+		"hasError":      false,
+	})
+}
+
+// handleDelete deletes a Record and all its Links
+func (s *Server) handleDelete(w http.ResponseWriter, busObID, recID string) {
+	bo := s.busOb(busObID)
+	if bo == nil {
+		writeError(w, http.StatusNotFound, "BUSINESSOBJECTNOTFOUND", "BusinessObject "+busObID+" not found")
+		return
+	}
+	for i, rec := range bo.records {
+		if rec.recID == recID {
+			bo.records = append(bo.records[:i], bo.records[i+1:]...)
+			for _, parents := range bo.links {
+				delete(parents, recID)
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"busObId":       bo.def.BusObID,
+				"busObRecId":    rec.recID,
+				"busObPublicId": bo.publicID(rec),
+				"hasError":      false,
+			})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "RECORDNOTFOUND", "Record not found")
+}
+
+// handleRelated returns the Records linked to a parent Record by a Relationship
+func (s *Server) handleRelated(w http.ResponseWriter, busObID, recID, relID string) {
+	bo, rel, target, ok := s.resolveRelationship(w, busObID, recID, relID)
+	if !ok {
+		return
+	}
+	parent := bo.record(recID, "")
+	related := []recordJSON{}
+	for _, childID := range bo.links[rel.RelationshipID][recID] {
+		if child := target.record(childID, ""); child != nil {
+			related = append(related, target.recordJSON(child, nil))
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"parentBusObId":          bo.def.BusObID,
+		"parentBusObRecId":       recID,
+		"parentBusObPublicId":    bo.publicID(parent),
+		"relationshipId":         rel.RelationshipID,
+		"relatedBusinessObjects": related,
+		"totalRecords":           len(related),
+		"pageNumber":             1,
+		"pageSize":               len(related),
+		"links":                  []struct{}{},
+	})
+}
+
+// handleLink links or unlinks a child Record to or from a parent Record
+func (s *Server) handleLink(w http.ResponseWriter, p map[string]string, link bool) {
+	bo, rel, target, ok := s.resolveRelationship(w, p["parentbusobid"], p["parentbusobrecid"], p["relationshipid"])
+	if !ok {
+		return
+	}
+	if !strings.EqualFold(target.def.BusObID, p["busobid"]) || target.record(p["busobrecid"], "") == nil {
+		writeError(w, http.StatusNotFound, "RECORDNOTFOUND", "Child Record not found")
+		return
+	}
+	if bo.links[rel.RelationshipID] == nil {
+		bo.links[rel.RelationshipID] = make(map[string][]string)
+	}
+	parentID, childID := p["parentbusobrecid"], p["busobrecid"]
+	children := bo.links[rel.RelationshipID][parentID]
+	idx := -1
+	for i, c := range children {
+		if c == childID {
+			idx = i
+		}
+	}
+	switch {
+	case link && idx < 0:
+		if rel.Cardinality == "One" {
+			children = nil
+		}
+		children = append(children, childID)
+	case !link && idx >= 0:
+		children = append(children[:idx], children[idx+1:]...)
+	}
+	bo.links[rel.RelationshipID][parentID] = children
+	writeJSON(w, http.StatusOK, apiError{})
+}
+
+// resolveRelationship looks up the parent BusinessObject and Record, the Relationship and its Target
+// and writes an Error if one of them does not exist
+func (s *Server) resolveRelationship(w http.ResponseWriter, busObID, recID, relID string) (*busOb, *Relationship, *busOb, bool) {
+	bo := s.busOb(busObID)
+	if bo == nil {
+		writeError(w, http.StatusNotFound, "BUSINESSOBJECTNOTFOUND", "BusinessObject "+busObID+" not found")
+		return nil, nil, nil, false
+	}
+	rel := bo.relationship(relID)
+	if rel == nil {
+		writeError(w, http.StatusNotFound, "RELATIONSHIPNOTFOUND", "Relationship "+relID+" not found")
+		return nil, nil, nil, false
+	}
+	target := s.busOb(rel.Target)
+	if target == nil {
+		writeError(w, http.StatusNotFound, "BUSINESSOBJECTNOTFOUND", "BusinessObject "+rel.Target+" not found")
+		return nil, nil, nil, false
+	}
+	if bo.record(recID, "") == nil {
+		writeError(w, http.StatusNotFound, "RECORDNOTFOUND", "Record not found")
+		return nil, nil, nil, false
+	}
+	return bo, rel, target, true
+}
+
+// match evaluates a Filter-Operator against a Value like Cherwell: case-insensitive and
+// numeric or chronological for gt and lt where possible
+func match(value, op, filter string) bool {
+	v, f := strings.ToLower(value), strings.ToLower(filter)
+	switch op {
+	case "eq":
+		return v == f
+	case "contains":
+		return strings.Contains(v, f)
+	case "startswith":
+		return strings.HasPrefix(v, f)
+	case "gt":
+		return value != "" && compare(value, filter) > 0
+	case "lt":
+		return value != "" && compare(value, filter) < 0
+	}
+	return false
+}
+
+// dateLayouts are the Layouts tried to compare Values chronologically.
+var dateLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "1/2/2006 3:04:05 PM", "1/2/2006", "2006-01-02"}
+
+// compare compares two Values numerically, chronologically or case-insensitive as Strings
+func compare(a, b string) int {
+	if x, err := strconv.ParseFloat(a, 64); err == nil {
+		if y, err := strconv.ParseFloat(b, 64); err == nil {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	}
+	for _, layout := range dateLayouts {
+		if x, err := time.Parse(layout, a); err == nil {
+			for _, l := range dateLayouts {
+				if y, err := time.Parse(l, b); err == nil {
+					switch {
+					case x.Before(y):
+						return -1
+					case x.After(y):
+						return 1
+					}
+					return 0
+				}
+			}
+		}
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// contains reports whether the Slice contains the String
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// writeError writes an Error Response of the Cherwell API
+func writeError(w http.ResponseWriter, status int, code, msg string) {
+	writeJSON(w, status, apiError{
+		ErrorCode:      code,
+		ErrorMessage:   msg,
+		HasError:       true,
+		HTTPStatusCode: strings.ReplaceAll(http.StatusText(status), " ", ""),
+	})
+}
+
+// writeJSON writes the given Value as JSON Response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
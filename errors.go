@@ -0,0 +1,112 @@
+package gocherwell
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel Errors returned (wrapped) by the Client and its Types.
+// Use errors.Is to check for them.
+var (
+	ErrNotFound        = errors.New("gocherwell: not found")
+	ErrUnauthorized    = errors.New("gocherwell: unauthorized")
+	ErrForbidden       = errors.New("gocherwell: forbidden")
+	ErrValidation      = errors.New("gocherwell: validation failed")
+	ErrNilReceiver     = errors.New("gocherwell: receiver cannot be nil")
+	ErrClientClosed    = errors.New("gocherwell: client closed")
+	ErrMultipleResults = errors.New("gocherwell: multiple results")
+)
+
+// APIError is returned whenever the Cherwell API answers with an HTTP-Error
+// or a Response which has its embedded Error set.
+type APIError struct {
+	Method                string
+	URI                   string
+	StatusCode            int
+	ErrorCode             string
+	ErrorMessage          string
+	HTTPStatusCode        string
+	FieldValidationErrors []interface{}
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	msg := e.ErrorMessage
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	if e.ErrorCode != "" {
+		msg = e.ErrorCode + ": " + msg
+	}
+	return fmt.Sprintf("gocherwell: %v %v: %v (%d)", e.Method, e.URI, msg, e.StatusCode)
+}
+
+// Is reports whether the APIError matches one of the sentinel Errors
+// ErrNotFound, ErrUnauthorized, ErrForbidden or ErrValidation.
+func (e *APIError) Is(target error) bool {
+	code := strings.ToUpper(e.ErrorCode)
+	status := strings.ToUpper(e.HTTPStatusCode)
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound || status == "NOTFOUND" || strings.Contains(code, "NOTFOUND")
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || status == "UNAUTHORIZED" ||
+			code == "INVALID_GRANT" || code == "INVALID_CLIENT"
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden || status == "FORBIDDEN"
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest || status == "BADREQUEST" ||
+			strings.Contains(code, "VALIDATION") || len(e.FieldValidationErrors) > 0
+	}
+	return false
+}
+
+// ParseError is returned by ParseExpr and ParseQuery for invalid Expressions.
+// Pos is the 1-based Position of the offending Token in Input, counted in Characters (Runes), not Bytes.
+type ParseError struct {
+	Input string
+	Pos   int
+	Token string
+	Msg   string
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("gocherwell: parse error at position %d: %v", e.Pos, e.Msg)
+	}
+	return fmt.Sprintf("gocherwell: parse error at position %d near %q: %v", e.Pos, e.Token, e.Msg)
+}
+
+// Is reports whether the ParseError matches ErrValidation.
+func (e *ParseError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// newAPIError builds an APIError from the embedded Error of a Cherwell Response
+func newAPIError(method, uri string, statusCode int, e Error) *APIError {
+	return &APIError{
+		Method:         strings.ToUpper(method),
+		URI:            uri,
+		StatusCode:     statusCode,
+		ErrorCode:      e.ErrorCode,
+		ErrorMessage:   e.ErrorMessage,
+		HTTPStatusCode: e.HTTPStatusCode,
+	}
+}
+
+// responseError returns the embedded Error as error if the Cherwell API flagged it.
+// It is promoted to every Type embedding Error and used by Client.request.
+func (e *Error) responseError() *Error {
+	if e == nil || (!e.HasError && e.ErrorCode == "" && e.ErrorMessage == "") {
+		return nil
+	}
+	return e
+}
+
+// errorResponse is implemented by every Type embedding Error.
+type errorResponse interface {
+	responseError() *Error
+}
@@ -0,0 +1,71 @@
+package gocherwell_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/itsscb/gocherwell"
+)
+
+func TestAPIErrorIs(t *testing.T) {
+	sentinels := []error{gocherwell.ErrNotFound, gocherwell.ErrUnauthorized, gocherwell.ErrForbidden, gocherwell.ErrValidation}
+	tests := []struct {
+		name string
+		err  *gocherwell.APIError
+		want []error
+	}{
+		{"404", &gocherwell.APIError{StatusCode: 404}, []error{gocherwell.ErrNotFound}},
+		{"not found status", &gocherwell.APIError{StatusCode: 500, HTTPStatusCode: "NotFound"}, []error{gocherwell.ErrNotFound}},
+		{"not found code", &gocherwell.APIError{StatusCode: 500, ErrorCode: "RECORDNOTFOUND"}, []error{gocherwell.ErrNotFound}},
+		{"401", &gocherwell.APIError{StatusCode: 401}, []error{gocherwell.ErrUnauthorized}},
+		{"unauthorized status", &gocherwell.APIError{StatusCode: 200, HTTPStatusCode: "Unauthorized"}, []error{gocherwell.ErrUnauthorized}},
+		{"invalid grant", &gocherwell.APIError{StatusCode: 400, ErrorCode: "invalid_grant"}, []error{gocherwell.ErrUnauthorized, gocherwell.ErrValidation}},
+		{"invalid client", &gocherwell.APIError{StatusCode: 400, ErrorCode: "invalid_client"}, []error{gocherwell.ErrUnauthorized, gocherwell.ErrValidation}},
+		{"403", &gocherwell.APIError{StatusCode: 403}, []error{gocherwell.ErrForbidden}},
+		{"forbidden status", &gocherwell.APIError{StatusCode: 200, HTTPStatusCode: "Forbidden"}, []error{gocherwell.ErrForbidden}},
+		{"400", &gocherwell.APIError{StatusCode: 400}, []error{gocherwell.ErrValidation}},
+		{"validation code", &gocherwell.APIError{StatusCode: 500, ErrorCode: "ValidationError"}, []error{gocherwell.ErrValidation}},
+		{"field validation errors", &gocherwell.APIError{StatusCode: 200, FieldValidationErrors: []interface{}{"required"}}, []error{gocherwell.ErrValidation}},
+		{"500", &gocherwell.APIError{StatusCode: 500}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fmt.Errorf("wrapped: %w", tt.err)
+			for _, sentinel := range sentinels {
+				want := false
+				for _, w := range tt.want {
+					want = want || w == sentinel
+				}
+				if got := errors.Is(err, sentinel); got != want {
+					t.Errorf("errors.Is(%v, %v) = %v, want %v", tt.err, sentinel, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseErrorIs(t *testing.T) {
+	tests := []struct {
+		target error
+		want   bool
+	}{
+		{gocherwell.ErrValidation, true},
+		{gocherwell.ErrNotFound, false},
+		{gocherwell.ErrUnauthorized, false},
+		{gocherwell.ErrForbidden, false},
+	}
+	_, err := gocherwell.ParseExpr(&gocherwell.BusinessObjectSchema{}, "Status = ")
+	var parseErr *gocherwell.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("ParseExpr() error = %v, want a ParseError", err)
+	}
+	for _, tt := range tests {
+		if got := errors.Is(err, tt.target); got != tt.want {
+			t.Errorf("errors.Is(%v, %v) = %v, want %v", err, tt.target, got, tt.want)
+		}
+	}
+	if got := errors.Is(&gocherwell.ParseError{Msg: "direct"}, gocherwell.ErrValidation); !got {
+		t.Errorf("errors.Is(ParseError, ErrValidation) = false, want true")
+	}
+}
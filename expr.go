@@ -0,0 +1,410 @@
+package gocherwell
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxBranches is the maximum Number of Searches a Query with Expressions is split into.
+const maxBranches = 32
+
+// Expr is a boolean Expression of Conditions, built with Cond, And, Or and Not and added to a Query with Match.
+//
+//	q := bo.NewQuery().Match(gocherwell.Or(
+//		gocherwell.Cond("Status", gocherwell.OperatorEq, "New"),
+//		gocherwell.And(
+//			gocherwell.Cond("Status", gocherwell.OperatorEq, "In Progress"),
+//			gocherwell.Not(gocherwell.Cond("Priority", gocherwell.OperatorEq, 5)),
+//		),
+//	))
+type Expr struct {
+	kind     exprKind
+	cond     Condition
+	children []Expr
+}
+
+// exprKind is the Kind of an Expr.
+type exprKind int
+
+// Kinds of Expr.
+const (
+	exprCond exprKind = iota
+	exprAnd
+	exprOr
+	exprNot
+)
+
+// node is an Expr with its Conditions resolved against the BusinessObjectSchema.
+type node struct {
+	kind     exprKind
+	def      *FieldDefinition
+	operator Operator
+	values   []string
+	children []node
+}
+
+// Cond returns an Expr matching the Values of the Field with the given FieldID, FullFieldID, Name or DisplayName
+// by the Operator, like the Conditions added with Where.
+func Cond(field string, op Operator, v ...interface{}) Expr {
+	return Expr{kind: exprCond, cond: Condition{Field: field, Operator: Operator(strings.ToLower(string(op))), Values: v}}
+}
+
+// And returns an Expr matching if all given Expressions match.
+func And(exprs ...Expr) Expr {
+	return Expr{kind: exprAnd, children: exprs}
+}
+
+// Or returns an Expr matching if any of the given Expressions matches.
+func Or(exprs ...Expr) Expr {
+	return Expr{kind: exprOr, children: exprs}
+}
+
+// Not returns an Expr matching if the given Expression does not match.
+func Not(e Expr) Expr {
+	return Expr{kind: exprNot, children: []Expr{e}}
+}
+
+// String returns the Expression like "(Status eq New OR Status eq In Progress)".
+func (e Expr) String() string {
+	parts := []string{}
+	for _, c := range e.children {
+		parts = append(parts, c.String())
+	}
+	switch e.kind {
+	case exprAnd:
+		return "(" + strings.Join(parts, " AND ") + ")"
+	case exprOr:
+		return "(" + strings.Join(parts, " OR ") + ")"
+	case exprNot:
+		return "NOT " + parts[0]
+	}
+	return e.cond.String()
+}
+
+// Match adds an Expression to the Query, which has to match in addition to all Conditions.
+// Cherwell only combines Filters with AND, or with OR on the same Field. Expressions with OR
+// across Fields are therefore split into several Searches, which are paged lazily and merged
+// without duplicates in the Order of the Fields given with OrderBy, then the RecID. NOT and
+// Conditions that can not be sent to Cherwell are applied to the Results by the Client.
+// Empty And and Or are invalid.
+func (q *Query) Match(e Expr) *Query {
+	q.exprs = append(q.exprs, e)
+	return q
+}
+
+// AllowScan allows Expressions that need a Search without any Filter, e.g. for a Branch of only
+// negated Conditions. Such a Search reads all BusinessObjectRecords of the BusinessObject, which are
+// then filtered by the Client, so it is rejected with ErrValidation by default.
+func (q *Query) AllowScan(enabled bool) *Query {
+	q.allowScan = enabled
+	return q
+}
+
+// compileExprs compiles the Conditions and Expressions of the Query into one Search per
+// Branch of their disjunctive Normal Form. The whole Expression is applied to the Results
+func (q *Query) compileExprs(c *compiledQuery) error {
+	root := Expr{kind: exprAnd}
+	for _, cond := range q.conditions {
+		root.children = append(root.children, Expr{kind: exprCond, cond: cond})
+	}
+	root.children = append(root.children, q.exprs...)
+
+	n, err := resolveExpr(c.schema, root)
+	if err != nil {
+		return err
+	}
+	n = n.normalize(false)
+	branches, err := n.branches()
+	if err != nil {
+		return err
+	}
+	c.expr = &n
+	if len(branches) > 1 {
+		if err := c.sortByRecID(); err != nil {
+			return err
+		}
+	}
+	exact := true
+	for _, branch := range branches {
+		search := c.search
+		search.Filters = []Filter{}
+		filtered := make(map[string]bool)
+		for _, lit := range branch {
+			exact = lit.pushDown(&search, filtered) && exact
+		}
+		if len(search.Filters) == 0 && !q.allowScan {
+			return fmt.Errorf("%w: Query needs a Search without Filter, which reads all Records of %v: "+
+				"use Conditions Cherwell can evaluate besides NOT or AllowScan", ErrValidation, c.schema.Name)
+		}
+		c.branches = append(c.branches, search)
+	}
+	if len(c.branches) == 1 {
+		c.search = c.branches[0]
+		c.branches = nil
+		if exact {
+			c.expr = nil
+		}
+	}
+	return nil
+}
+
+// describe returns the Conditions and Expressions of the Query for Error messages
+func (q *Query) describe() string {
+	parts := []string{}
+	for _, c := range q.conditions {
+		parts = append(parts, c.String())
+	}
+	for _, e := range q.exprs {
+		parts = append(parts, e.String())
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// resolveExpr resolves the Fields of all Conditions of an Expr and formats their Values
+func resolveExpr(sch *BusinessObjectSchema, e Expr) (node, error) {
+	n := node{kind: e.kind}
+	if e.kind == exprCond {
+		def, err := sch.FieldDefinition(e.cond.Field)
+		if err != nil {
+			return n, err
+		}
+		n.def, n.operator = def, e.cond.Operator
+		for _, v := range e.cond.Values {
+			n.values = append(n.values, formatValue(def, v))
+		}
+		return n, validateCondition(e.cond, len(n.values))
+	}
+	if e.kind == exprNot && len(e.children) != 1 {
+		return n, fmt.Errorf("%w: Expression invalid: NOT needs 1 Expression, got %v", ErrValidation, len(e.children))
+	}
+	if len(e.children) == 0 {
+		op := "AND"
+		if e.kind == exprOr {
+			op = "OR"
+		}
+		return n, fmt.Errorf("%w: Expression invalid: %v needs at least 1 Expression", ErrValidation, op)
+	}
+	for _, child := range e.children {
+		c, err := resolveExpr(sch, child)
+		if err != nil {
+			return n, err
+		}
+		n.children = append(n.children, c)
+	}
+	return n, nil
+}
+
+// normalize returns the Node with NOT pushed down to the Conditions, negated if negate is set
+func (n node) normalize(negate bool) node {
+	switch n.kind {
+	case exprCond:
+		if negate {
+			return node{kind: exprNot, children: []node{n}}
+		}
+		return n
+	case exprNot:
+		return n.children[0].normalize(!negate)
+	}
+	res := node{kind: n.kind}
+	if negate {
+		res.kind = exprAnd + exprOr - n.kind
+	}
+	for _, c := range n.children {
+		res.children = append(res.children, c.normalize(negate))
+	}
+	return res
+}
+
+// branches returns the disjunctive Normal Form of a normalized Node as Branches of Literals, which are
+// Conditions, negated Conditions or ORs of Conditions on the same Field
+func (n node) branches() ([][]node, error) {
+	switch {
+	case n.kind == exprCond || n.kind == exprNot || n.sameFieldOr():
+		return [][]node{{n}}, nil
+	case n.kind == exprOr:
+		res := [][]node{}
+		for _, c := range n.children {
+			b, err := c.branches()
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, b...)
+			if len(res) > maxBranches {
+				return nil, fmt.Errorf("%w: Query too complex: more than %v Searches needed", ErrValidation, maxBranches)
+			}
+		}
+		return res, nil
+	}
+	res := [][]node{{}}
+	for _, c := range n.children {
+		b, err := c.branches()
+		if err != nil {
+			return nil, err
+		}
+		if len(res)*len(b) > maxBranches {
+			return nil, fmt.Errorf("%w: Query too complex: more than %v Searches needed", ErrValidation, maxBranches)
+		}
+		product := [][]node{}
+		for _, left := range res {
+			for _, right := range b {
+				product = append(product, append(append([]node{}, left...), right...))
+			}
+		}
+		res = product
+	}
+	return res, nil
+}
+
+// sameFieldOr reports whether the Node is an OR of Conditions on a single Field, which Cherwell evaluates itself
+func (n node) sameFieldOr() bool {
+	if n.kind != exprOr || len(n.children) == 0 {
+		return false
+	}
+	for _, c := range n.children {
+		if c.kind != exprCond || c.def.FieldID != n.children[0].def.FieldID {
+			return false
+		}
+	}
+	return true
+}
+
+// pushDown adds the Filters of a Literal to the Search, unless its Field is filtered already.
+// It reports whether Cherwell evaluates the Literal exactly
+func (n node) pushDown(search *Search, filtered map[string]bool) bool {
+	conds := []node{n}
+	switch {
+	case n.sameFieldOr():
+		conds = n.children
+	case n.kind != exprCond:
+		return false
+	}
+	def := conds[0].def
+	if filtered[def.FieldID] {
+		return false
+	}
+	filtered[def.FieldID] = true
+	exact := true
+	for _, c := range conds {
+		filter := func(op Operator, value string) {
+			search.Filters = append(search.Filters, Filter{FieldID: def.FieldID, FieldName: def.Name, Operator: string(op), Value: value})
+		}
+		switch c.operator {
+		case OperatorIn:
+			for _, v := range c.values {
+				filter(OperatorEq, v)
+			}
+		case OperatorBetween:
+			filter(OperatorGt, c.values[0])
+			filter(OperatorEq, c.values[0])
+			exact = false
+		default:
+			filter(c.operator, c.values[0])
+		}
+	}
+	return exact
+}
+
+// eval reports whether the BusinessObjectRecord matches the Node
+func (n node) eval(rec *BusinessObjectRecord) bool {
+	switch n.kind {
+	case exprAnd:
+		for _, c := range n.children {
+			if !c.eval(rec) {
+				return false
+			}
+		}
+		return true
+	case exprOr:
+		for _, c := range n.children {
+			if c.eval(rec) {
+				return true
+			}
+		}
+		return false
+	case exprNot:
+		return !n.children[0].eval(rec)
+	}
+	value := ""
+	if f, err := rec.Field(n.def.FieldID); err == nil {
+		value = f.Value
+	}
+	switch n.operator {
+	case OperatorIn:
+		return matchValue(n.def, value, OperatorIn, strings.Join(n.values, "\x00"))
+	case OperatorBetween:
+		return matchValue(n.def, value, operatorGe, n.values[0]) && matchValue(n.def, value, operatorLe, n.values[1])
+	}
+	return matchValue(n.def, value, n.operator, n.values[0])
+}
+
+// fields returns the FieldIDs of all Conditions of the Node
+func (n node) fields() []string {
+	if n.kind == exprCond {
+		return []string{n.def.FieldID}
+	}
+	res := []string{}
+	for _, c := range n.children {
+		res = append(res, c.fields()...)
+	}
+	return res
+}
+
+// sortByRecID adds the RecID Field as last Sort Field, so all Branches return their Results in
+// the Order of less and can be merged while paging. Without RecID Field the Order of Records with
+// equal Sort Fields is undefined, so it returns ErrValidation.
+func (c *compiledQuery) sortByRecID() error {
+	if c.schema.FirstRecIDField == "" {
+		return fmt.Errorf("%w: BusinessObjectSchema of %v has no RecID Field to merge the Searches of the Query", ErrValidation, c.schema.Name)
+	}
+	def, err := c.schema.FieldDefinition(c.schema.FirstRecIDField)
+	if err != nil {
+		return fmt.Errorf("%w: RecID Field of %v to merge the Searches of the Query: %v", ErrValidation, c.schema.Name, err)
+	}
+	c.recIDField = def.FieldID
+	for _, s := range c.search.Sorting {
+		if sameID(s.FieldID, def.FieldID) {
+			return nil
+		}
+	}
+	c.search.Sorting = append(c.search.Sorting, Sort{FieldID: def.FieldID, SortDirection: int64(SortAscending)})
+	return nil
+}
+
+// sortKey returns the Values of the Sort Fields of a BusinessObjectRecord except the RecID Field.
+// As all Branches are sorted by RecID last, a BusinessObjectRecord found by several Branches is
+// merged among the Records with the same sortKey
+func (c *compiledQuery) sortKey(rec *BusinessObjectRecord) string {
+	values := []string{}
+	for _, s := range c.search.Sorting {
+		if sameID(s.FieldID, c.recIDField) {
+			continue
+		}
+		v := ""
+		if f, err := rec.Field(s.FieldID); err == nil {
+			v = f.Value
+		}
+		values = append(values, v)
+	}
+	return strings.Join(values, "\x00")
+}
+
+// less reports whether the BusinessObjectRecord a comes before b by the Sorting of the Search, then by BusObRecID
+func (c *compiledQuery) less(a, b *BusinessObjectRecord) bool {
+	for _, s := range c.search.Sorting {
+		def, err := c.schema.FieldDefinition(s.FieldID)
+		if err != nil {
+			continue
+		}
+		x, y := "", ""
+		if f, err := a.Field(s.FieldID); err == nil {
+			x = f.Value
+		}
+		if f, err := b.Field(s.FieldID); err == nil {
+			y = f.Value
+		}
+		if cmp := compareValues(def, x, y); cmp != 0 {
+			return (cmp < 0) == (s.SortDirection >= 0)
+		}
+	}
+	return a.BusObRecID < b.BusObRecID
+}
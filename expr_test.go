@@ -0,0 +1,214 @@
+package gocherwell_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/itsscb/gocherwell"
+	"github.com/itsscb/gocherwell/cherwelltest"
+)
+
+func TestQueryMatch(t *testing.T) {
+	phone := gocherwell.Cond("AssetType", gocherwell.OperatorEq, "Phone")
+	expensive := gocherwell.Cond("Cost", gocherwell.OperatorGt, 250)
+	retired := gocherwell.Cond("Status", gocherwell.OperatorEq, "Retired")
+	tests := []struct {
+		name     string
+		expr     gocherwell.Expr
+		pageSize int
+		want     func(i int) bool
+		searches int
+	}{
+		{"or on the same field", gocherwell.Or(phone, gocherwell.Cond("AssetType", gocherwell.OperatorEq, "Desktop")), 0,
+			func(i int) bool { return i%3 != 0 }, 1},
+		{"or across fields", gocherwell.Or(phone, expensive), 0,
+			func(i int) bool { return i%3 == 2 || i > 25 }, 2},
+		{"or across fields in small pages", gocherwell.Or(phone, expensive), 3,
+			func(i int) bool { return i%3 == 2 || i > 25 }, 2},
+		{"and of or", gocherwell.And(gocherwell.Or(phone, expensive), gocherwell.Or(retired, gocherwell.Cond("Cost", gocherwell.OperatorLt, 100))), 0,
+			func(i int) bool { return (i%3 == 2 || i > 25) && (i%3 == 0 || i < 10) }, 4},
+		{"not is applied by the client", gocherwell.And(gocherwell.Or(phone, expensive), gocherwell.Not(retired)), 0,
+			func(i int) bool { return (i%3 == 2 || i > 25) && i%3 != 0 }, 2},
+		{"between", gocherwell.Or(gocherwell.Cond("Cost", gocherwell.OperatorBetween, 50, 100), phone), 0,
+			func(i int) bool { return (i >= 5 && i <= 10) || i%3 == 2 }, 2},
+		{"overlapping branches", gocherwell.Or(phone, gocherwell.Cond("Cost", gocherwell.OperatorGt, 0)), 4,
+			func(i int) bool { return i > 0 }, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer()
+			defer srv.Close()
+			ctx := context.Background()
+			cl := srv.NewClient()
+			bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+			if err != nil {
+				t.Fatal(err)
+			}
+			recs, err := bo.NewQuery().Match(tt.expr).PageSize(tt.pageSize).Records(ctx, cl)
+			if err != nil {
+				t.Fatalf("Records() error = %v", err)
+			}
+			if got, want := assetNames(recs), expectedNames(tt.want); strings.Join(got, ",") != strings.Join(want, ",") {
+				t.Errorf("Records() = %v, want %v", got, want)
+			}
+			if got := searchesByFilters(srv); got != tt.searches {
+				t.Errorf("Searches = %v, want %v", got, tt.searches)
+			}
+		})
+	}
+}
+
+func TestQueryMatchOrderBy(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	ctx := context.Background()
+	cl := srv.NewClient()
+	bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := bo.NewQuery().
+		Match(gocherwell.Or(gocherwell.Cond("AssetType", gocherwell.OperatorEq, "Phone"), gocherwell.Cond("Cost", gocherwell.OperatorLt, 50))).
+		OrderBy("Cost", gocherwell.SortDescending).
+		PageSize(4)
+	recs, err := q.Records(ctx, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"NB029", "NB026", "NB023", "NB020", "NB017", "NB014", "NB011", "NB008", "NB005", "NB004", "NB003", "NB002", "NB001", "NB000"}
+	if got := assetNames(recs); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Records() = %v, want %v", got, want)
+	}
+}
+
+func TestQueryMatchFirstIsLazy(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	ctx := context.Background()
+	cl := srv.NewClient()
+	bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec, err := bo.NewQuery().
+		Match(gocherwell.Or(gocherwell.Cond("AssetType", gocherwell.OperatorEq, "Phone"), gocherwell.Cond("Cost", gocherwell.OperatorGt, 0))).
+		PageSize(2).
+		First(ctx, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.FieldValues["AssetName"] != "NB001" {
+		t.Errorf("First() = %v, want NB001", rec.FieldValues["AssetName"])
+	}
+	if got := countRequests(srv, "getsearchresults"); got != 2 {
+		t.Errorf("Requests = %v, want one Page per Search", got)
+	}
+}
+
+func TestQueryMatchInvalid(t *testing.T) {
+	retired := gocherwell.Cond("Status", gocherwell.OperatorEq, "Retired")
+	tests := []struct {
+		name      string
+		expr      gocherwell.Expr
+		allowScan bool
+		records   int
+		err       error
+	}{
+		{"empty and", gocherwell.And(), false, 0, gocherwell.ErrValidation},
+		{"empty or", gocherwell.Or(retired, gocherwell.Or()), false, 0, gocherwell.ErrValidation},
+		{"only not", gocherwell.Not(retired), false, 0, gocherwell.ErrValidation},
+		{"branch of only not", gocherwell.Or(retired, gocherwell.Not(gocherwell.Cond("AssetType", gocherwell.OperatorEq, "Phone"))), false, 0, gocherwell.ErrValidation},
+		{"only not with scan", gocherwell.Not(retired), true, 20, nil},
+		{"branch of only not with scan", gocherwell.Or(retired, gocherwell.Not(gocherwell.Cond("AssetType", gocherwell.OperatorEq, "Phone"))), true, 20, nil},
+		{"unknown field", gocherwell.Or(retired, gocherwell.Cond("Missing", gocherwell.OperatorEq, "x")), false, 0, gocherwell.ErrNotFound},
+	}
+	srv := newTestServer()
+	defer srv.Close()
+	ctx := context.Background()
+	cl := srv.NewClient()
+	bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recs, err := bo.NewQuery().Match(tt.expr).AllowScan(tt.allowScan).Records(ctx, cl)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Records() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && len(*recs) != tt.records {
+				t.Errorf("Records() = %v, want %v", assetNames(recs), tt.records)
+			}
+		})
+	}
+}
+
+func TestQueryMatchWithoutRecIDField(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	// proxy answers like srv, but with a Schema without RecID Field
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := httptest.NewRecorder()
+		srv.Config.Handler.ServeHTTP(rec, r)
+		body := rec.Body.Bytes()
+		if strings.Contains(strings.ToLower(r.URL.Path), "getbusinessobjectschema") {
+			body = bytes.ReplaceAll(body, []byte(`"firstRecIdField":"FIRecID"`), []byte(`"firstRecIdField":""`))
+		}
+		for k, v := range rec.Header() {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(rec.Code)
+		_, _ = w.Write(body)
+	}))
+	defer proxy.Close()
+	ctx := context.Background()
+	cl := gocherwell.NewClient(srv.User, srv.Password, srv.ClientID, proxy.URL+"/", "Internal", "password")
+	bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = bo.NewQuery().
+		Match(gocherwell.Or(gocherwell.Cond("AssetType", gocherwell.OperatorEq, "Phone"), gocherwell.Cond("Cost", gocherwell.OperatorGt, 250))).
+		Records(ctx, cl)
+	if !errors.Is(err, gocherwell.ErrValidation) {
+		t.Errorf("Records() error = %v, want %v", err, gocherwell.ErrValidation)
+	}
+	if got := countRequests(srv, "getsearchresults"); got != 0 {
+		t.Errorf("Requests = %v, want no Search", got)
+	}
+}
+
+// expectedNames returns the AssetNames of the Records of newTestServer matching the given Predicate in Order of their RecIDs
+func expectedNames(match func(i int) bool) []string {
+	names := []string{}
+	for i := 0; i < 30; i++ {
+		if match(i) {
+			names = append(names, fmt.Sprintf("NB%03d", i))
+		}
+	}
+	return names
+}
+
+// searchesByFilters returns the Number of distinct Filters sent to getsearchresults
+func searchesByFilters(srv *cherwelltest.Server) int {
+	filters := map[string]bool{}
+	for _, r := range srv.Requests() {
+		if !strings.Contains(strings.ToLower(r.Path), "getsearchresults") {
+			continue
+		}
+		search := struct {
+			Filters json.RawMessage `json:"filters"`
+		}{}
+		if err := json.Unmarshal([]byte(r.Body), &search); err == nil {
+			filters[string(search.Filters)] = true
+		}
+	}
+	return len(filters)
+}
@@ -1,908 +1,1126 @@
-// gocherwell is a go-Module for communication with the REST-API of Cherwell
-package gocherwell
-
-import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io"
-	"io/ioutil"
-	"net/http"
-	"net/url"
-	"strings"
-	"time"
-)
-
-// Cherwell API URIs.
-const (
-	deleteBusObRecURI        = "api/V1/deletebusinessobject/busobid/$/busobrecid/#"
-	saveBusObRecURI          = "api/V1/savebusinessobject"
-	getSearchResultsURI      = "api/V1/getsearchresults"
-	getBusObTemplateURI      = "api/V1/getbusinessobjecttemplate"
-	getQuickSearchResultsURI = "api/V1/getquicksearchresults"
-	getBusObRecByRecIdURI    = "api/v1/getbusinessobject/busobid/$/busobrecid/#"
-	getBusObRecByPublicIdURI = "api/v1/getbusinessobject/busobid/$/publicid/*"
-	getBusObSchemaURI        = "api/v1/getbusinessobjectschema/busobid/$?includerelationships=true"
-	getBusObSummariesAllURI  = "api/v1/getbusinessobjectsummaries/type/All"
-	getRelatedBusObURI       = "api/V1/getrelatedbusinessobject/parentbusobid/$/parentbusobrecid/#/relationshipid/?"
-	linkBusObRecURI          = "api/V2/linkrelatedbusinessobject/parentbusobid/$/parentbusobrecid/#/relationshipid/?/busobid/&/busobrecid/+"
-	unlinkBusObRecURI        = "api/V1/unlinkrelatedbusinessobject/parentbusobid/$/parentbusobrecid/#/relationshipid/?/busobid/&/busobrecid/+"
-)
-
-// Mapping of the Placeholders for the Cherwell API URIs.
-const (
-	busobid         = "$"
-	busobrecid      = "#"
-	busobpublicid   = "*"
-	relationshipid  = "?"
-	childbusobid    = "&"
-	childbusobrecid = "+"
-)
-
-// Client contains the necessary Values to communicate with the Cherwell API.
-type Client struct {
-	User          string `json:"username"`
-	Password      string `json:"password,omitempty"`
-	ClientID      string `json:"as:client_id"`
-	BaseURI       string `json:"-"`
-	Expires       string `json:".expires,omitempty"`
-	Issued        string `json:".issued,omitempty"`
-	Access_token  string `json:"access_token,omitempty"`
-	Token_type    string `json:"token_type"`
-	Refresh_token string `json:"refresh_token,omitempty"`
-	Expires_in    int    `json:"expires_in,omitempty"`
-	Accept        string `json:"Accept,omitempty"`
-	Grant_Type    string `json:"grant_type,omitempty"`
-	Auth_mode     string `json:"-"`
-}
-
-// BusinessObject contains the Values of a Cherwell BusinessObject.
-type BusinessObject struct {
-	BusObID         string           `json:"busObId,omitempty"`
-	Name            string           `json:"name,omitempty"`
-	DisplayName     string           `json:"displayName,omitempty"`
-	FirstRecIdField string           `json:"firstRecIdField,omitempty"`
-	GroupSummaries  []BusinessObject `json:"groupSummaries,omitempty"`
-	RecIdFields     string           `json:"recIdFields,omitempty"`
-	StateFieldId    string           `json:"stateFieldId,omitempty"`
-	States          string           `json:"states,omitempty"`
-	Group           bool             `json:"group,omitempty"`
-	Lookup          bool             `json:"lookup,omitempty"`
-	Major           bool             `json:"major,omitempty"`
-	Supporting      bool             `json:"supporting,omitempty"`
-}
-
-// BusinessObject contains the Values of a Cherwell BusinessObjectSchema.
-type BusinessObjectSchema struct {
-	BusObID string `json:"busObId"`
-	Error
-	FieldDefinitions []struct {
-		AutoFill             bool   `json:"autoFill"`
-		Calculated           bool   `json:"calculated"`
-		Category             string `json:"category"`
-		DecimalDigits        int64  `json:"decimalDigits"`
-		Description          string `json:"description"`
-		Details              string `json:"details"`
-		DisplayName          string `json:"displayName"`
-		Enabled              bool   `json:"enabled"`
-		FieldID              string `json:"fieldId"`
-		HasDate              bool   `json:"hasDate"`
-		HasTime              bool   `json:"hasTime"`
-		IsFullTextSearchable bool   `json:"isFullTextSearchable"`
-		MaximumSize          string `json:"maximumSize"`
-		Name                 string `json:"name"`
-		ReadOnly             bool   `json:"readOnly"`
-		Required             bool   `json:"required"`
-		Type                 string `json:"type"`
-		TypeLocalized        string `json:"typeLocalized"`
-		Validated            bool   `json:"validated"`
-		WholeDigits          int64  `json:"wholeDigits"`
-	} `json:"fieldDefinitions"`
-	FirstRecIDField string `json:"firstRecIdField"`
-	GridDefinitions []struct {
-		DisplayName string `json:"displayName"`
-		GridID      string `json:"gridId"`
-		Name        string `json:"name"`
-	} `json:"gridDefinitions"`
-	HTTPStatusCode string `json:"httpStatusCode"`
-	Name           string `json:"name"`
-	RecIDFields    string `json:"recIdFields"`
-	Relationships  []struct {
-		Cardinality      string `json:"cardinality"`
-		Description      string `json:"description"`
-		DisplayName      string `json:"displayName"`
-		FieldDefinitions []struct {
-			AutoFill             bool   `json:"autoFill"`
-			Calculated           bool   `json:"calculated"`
-			Category             string `json:"category"`
-			DecimalDigits        int64  `json:"decimalDigits"`
-			Description          string `json:"description"`
-			Details              string `json:"details"`
-			DisplayName          string `json:"displayName"`
-			Enabled              bool   `json:"enabled"`
-			FieldID              string `json:"fieldId"`
-			HasDate              bool   `json:"hasDate"`
-			HasTime              bool   `json:"hasTime"`
-			IsFullTextSearchable bool   `json:"isFullTextSearchable"`
-			MaximumSize          string `json:"maximumSize"`
-			Name                 string `json:"name"`
-			ReadOnly             bool   `json:"readOnly"`
-			Required             bool   `json:"required"`
-			Type                 string `json:"type"`
-			TypeLocalized        string `json:"typeLocalized"`
-			Validated            bool   `json:"validated"`
-			WholeDigits          int64  `json:"wholeDigits"`
-		} `json:"fieldDefinitions"`
-		RelationshipID string `json:"relationshipId"`
-		Target         string `json:"target"`
-	} `json:"relationships"`
-	StateFieldID string `json:"stateFieldId"`
-	States       string `json:"states"`
-}
-
-// BusinessObject contains the Values of a Cherwell BusinessObjectTemplate.
-type BusinessObjectTemplate struct {
-	Error
-	Fields []Field `json:"fields"`
-}
-
-// BusinessObject contains the Values of a Cherwell BusinessObjectRecord
-// and is used to Unmarshal multiple HTTP-Responses of the Cherwell API.
-type BusinessObjectRecord struct {
-	BusObID               string        `json:"busObId,omitempty"`
-	BusObRecID            string        `json:"busObRecId,omitempty"`
-	BusObPublicID         string        `json:"busObPublicId,omitempty"`
-	CacheKey              string        `json:"cacheKey,omitempty"`
-	CacheScope            string        `json:"cacheScope,omitempty"`
-	Fields                []Field       `json:"fields,omitempty"`
-	Persist               bool          `json:"persist,omitempty"`
-	FieldValidationErrors []interface{} `json:"fieldValidationErrors,omitempty"`
-	Error
-	NotificationTriggers []interface{}          `json:"notificationTriggers,omitempty"`
-	Links                []Link                 `json:"links,omitempty"`
-	FieldValues          map[string]interface{} `json:"-"`
-}
-
-// RelatedBusinessObjects is used to Unmarshal the HTTP-Response of the Cherwell API
-// regarding related BusinessObjects.
-type RelatedBusinessObjects struct {
-	Error
-	Links                  []Link                 `json:"links"`
-	PageNumber             int64                  `json:"pageNumber"`
-	PageSize               int64                  `json:"pageSize"`
-	ParentBusObID          string                 `json:"parentBusObId"`
-	ParentBusObPublicID    string                 `json:"parentBusObPublicId"`
-	ParentBusObRecID       string                 `json:"parentBusObRecId"`
-	RelatedBusinessObjects []BusinessObjectRecord `json:"relatedBusinessObjects"`
-	RelationshipID         string                 `json:"relationshipId"`
-	TotalRecords           int64                  `json:"totalRecords"`
-}
-
-// Search is used to Marshal the HTTP-Request to the Cherwell API
-// regarding Searches.
-type Search struct {
-	Association        string   `json:"association,omitempty"`
-	BusObID            string   `json:"busObId,omitempty"`
-	CustomGridDefID    string   `json:"customGridDefId,omitempty"`
-	DateTimeFormatting string   `json:"dateTimeFormatting,omitempty"`
-	FieldID            string   `json:"fieldId,omitempty"`
-	Fields             []string `json:"fields,omitempty"`
-	Filters            []Filter `json:"filters,omitempty"`
-	IncludeAllFields   bool     `json:"includeAllFields,omitempty"`
-	IncludeSchema      bool     `json:"includeSchema,omitempty"`
-	PageNumber         int64    `json:"pageNumber,omitempty"`
-	PageSize           int64    `json:"pageSize,omitempty"`
-	PromptValues       []struct {
-		BusObID                  string   `json:"busObId,omitempty"`
-		CollectionStoreEntireRow string   `json:"collectionStoreEntireRow,omitempty"`
-		CollectionValueField     string   `json:"collectionValueField,omitempty"`
-		FieldID                  string   `json:"fieldId,omitempty"`
-		ListReturnFieldID        string   `json:"listReturnFieldId,omitempty"`
-		PromptID                 string   `json:"promptId,omitempty"`
-		Value                    struct{} `json:"value,omitempty"`
-		ValueIsRecID             bool     `json:"valueIsRecId,omitempty"`
-	} `json:"promptValues,omitempty"`
-	Scope      string `json:"scope,omitempty"`
-	ScopeOwner string `json:"scopeOwner,omitempty"`
-	SearchID   string `json:"searchId,omitempty"`
-	SearchName string `json:"searchName,omitempty"`
-	SearchText string `json:"searchText,omitempty"`
-	Sorting    []struct {
-		FieldID       string `json:"fieldId,omitempty"`
-		SortDirection int64  `json:"sortDirection,omitempty"`
-	} `json:"sorting,omitempty"`
-}
-
-// SearchResult is used to Unmarshal the HTTP-Response of the Cherwell API
-// regarding Searches.
-type SearchResult struct {
-	BusinessObjects []BusinessObjectRecord `json:"businessObjects,omitempty"`
-	Error
-	HasPrompts bool   `json:"hasPrompts,omitempty"`
-	Links      []Link `json:"links,omitempty"`
-	Prompts    []struct {
-		AllowValuesOnly          bool     `json:"allowValuesOnly,omitempty"`
-		BusObID                  string   `json:"busObId,omitempty"`
-		CollectionStoreEntireRow string   `json:"collectionStoreEntireRow,omitempty"`
-		CollectionValueField     string   `json:"collectionValueField,omitempty"`
-		ConstraintXML            string   `json:"constraintXml,omitempty"`
-		Contents                 string   `json:"contents,omitempty"`
-		Default                  string   `json:"default,omitempty"`
-		FieldID                  string   `json:"fieldId,omitempty"`
-		IsDateRange              bool     `json:"isDateRange,omitempty"`
-		ListDisplayOption        string   `json:"listDisplayOption,omitempty"`
-		ListReturnFieldID        string   `json:"listReturnFieldId,omitempty"`
-		MultiLine                bool     `json:"multiLine,omitempty"`
-		PromptID                 string   `json:"promptId,omitempty"`
-		PromptType               string   `json:"promptType,omitempty"`
-		PromptTypeName           string   `json:"promptTypeName,omitempty"`
-		Required                 bool     `json:"required,omitempty"`
-		Text                     string   `json:"text,omitempty"`
-		Value                    struct{} `json:"value,omitempty"`
-		Values                   []string `json:"values,omitempty"`
-	} `json:"prompts,omitempty"`
-	SearchResultsFields []struct {
-		Caption                   string `json:"caption,omitempty"`
-		CurrencyCulture           string `json:"currencyCulture,omitempty"`
-		CurrencySymbol            string `json:"currencySymbol,omitempty"`
-		DecimalDigits             int64  `json:"decimalDigits,omitempty"`
-		DefaultSortOrderAscending bool   `json:"defaultSortOrderAscending,omitempty"`
-		DisplayName               string `json:"displayName,omitempty"`
-		FieldID                   string `json:"fieldId,omitempty"`
-		FieldName                 string `json:"fieldName,omitempty"`
-		FullFieldID               string `json:"fullFieldId,omitempty"`
-		HasDefaultSortField       bool   `json:"hasDefaultSortField,omitempty"`
-		IsBinary                  bool   `json:"isBinary,omitempty"`
-		IsCurrency                bool   `json:"isCurrency,omitempty"`
-		IsDateTime                bool   `json:"isDateTime,omitempty"`
-		IsFilterAllowed           bool   `json:"isFilterAllowed,omitempty"`
-		IsLogical                 bool   `json:"isLogical,omitempty"`
-		IsNumber                  bool   `json:"isNumber,omitempty"`
-		IsShortDate               bool   `json:"isShortDate,omitempty"`
-		IsShortTime               bool   `json:"isShortTime,omitempty"`
-		IsVisible                 bool   `json:"isVisible,omitempty"`
-		SortOrder                 string `json:"sortOrder,omitempty"`
-		Sortable                  bool   `json:"sortable,omitempty"`
-		StorageName               string `json:"storageName,omitempty"`
-		WholeDigits               int64  `json:"wholeDigits,omitempty"`
-	} `json:"searchResultsFields,omitempty"`
-	SimpleResults struct {
-		Error
-		Groups []struct {
-			Error
-			IsBusObTarget          bool                    `json:"isBusObTarget,omitempty"`
-			SimpleResultsListItems []SimpleResultsListItem `json:"simpleResultsListItems,omitempty"`
-			SubTitle               string                  `json:"subTitle,omitempty"`
-			TargetID               string                  `json:"targetId,omitempty"`
-			Title                  string                  `json:"title,omitempty"`
-		} `json:"groups,omitempty"`
-		Title string `json:"title,omitempty"`
-	} `json:"simpleResults,omitempty"`
-	TotalRows int64 `json:"totalRows,omitempty"`
-}
-
-// SimplehResultsListItem is used to Unmarshal multiple HTTP-Responses of the Cherwell API
-// regarding Searches.
-// Extends SearchResult
-type SimpleResultsListItem struct {
-	BusinessObjectRecord
-	DocRepositoryItemID string `json:"docRepositoryItemId,omitempty"`
-	GalleryImage        string `json:"galleryImage,omitempty"`
-	PublicID            string `json:"publicId,omitempty"`
-	Scope               string `json:"scope,omitempty"`
-	ScopeOwner          string `json:"scopeOwner,omitempty"`
-	SubTitle            string `json:"subTitle,omitempty"`
-	Text                string `json:"text,omitempty"`
-	Title               string `json:"title,omitempty"`
-}
-
-// Filter is used to Marshal the HTTP-Response to the Cherwell API
-// regarding Searches.
-// Extends Search
-type Filter struct {
-	FieldID   string `json:"fieldId,omitempty"`
-	FieldName string `json:"-"`
-	Operator  string `json:"operator,omitempty"`
-	Value     string `json:"value,omitempty"`
-}
-
-// Field is used to Marshal the HTTP-Response to the Cherwell API.
-// Extends multiple Types
-type Field struct {
-	Dirty       bool   `json:"dirty,omitempty"`
-	DisplayName string `json:"displayName,omitempty"`
-	Html        string `json:"html,omitempty"`
-	Name        string `json:"name,omitempty"`
-	Value       string `json:"value,omitempty"`
-	FieldID     string `json:"fieldId,omitempty"`
-	FullFieldID string `json:"fullFieldId"`
-}
-
-// Error is used to Marshal the HTTP-Response to the Cherwell API.
-// Extends multiple Types
-type Error struct {
-	ErrorCode      string `json:"errorCode,omitempty"`
-	ErrorMessage   string `json:"errorMessage,omitempty"`
-	HasError       bool   `json:"hasError,omitempty"`
-	HTTPStatusCode string `json:"httpStatusCode"`
-}
-
-// Link is used to Marshal the HTTP-Response to the Cherwell API.
-// Extends multiple Types
-type Link struct {
-	Name string `json:"name,omitempty"`
-	URL  string `json:"url,omitempty"`
-}
-
-// NewClient returns a Pointer to an Instance of a Cherwell Client
-func NewClient(user, password, clientID, baseURI, auth_mode, grant_type string) *Client {
-	return &Client{
-		User:       user,
-		Password:   password,
-		ClientID:   clientID,
-		BaseURI:    baseURI,
-		Auth_mode:  auth_mode,
-		Grant_Type: grant_type,
-	}
-}
-
-// Login authenticates with the Cherwell Server and retreives an AccessToken, saves it
-// in the Instance of the Client and returns a Pointer to the Instance of the Client
-func (cl *Client) Login() *Client {
-	params := url.Values{}
-	params.Add("grant_type", cl.Grant_Type)
-	params.Add("client_id", cl.ClientID)
-	params.Add("username", cl.User)
-	if cl.Password != "" {
-		params.Add("password", cl.Password)
-	}
-	body := strings.NewReader(params.Encode())
-
-	req, err := http.NewRequest("POST", (cl.BaseURI + "token?auth_mode=" + cl.Auth_mode), body)
-	if err != nil {
-		fmt.Printf("\nLogin failed @ NewRequest: %v", err)
-		return nil
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		fmt.Printf("\nLogin failed @ DoRequest: %v", err)
-		return nil
-	}
-	defer resp.Body.Close()
-	err = unJson(resp.Body, &cl)
-	if err != nil {
-		fmt.Printf("\nLogin failed @ unJson: %v", err)
-		return cl
-	}
-	return cl
-}
-
-// keepAlive sends the RefreshToken to the Cherwell Server and retreives a new AccessToken and returns a bool
-func (cl *Client) keepAlive() bool {
-	params := url.Values{}
-	params.Add("grant_type", "refresh_token")
-	params.Add("client_id", cl.ClientID)
-	params.Add("refresh_token", cl.Refresh_token)
-	body := strings.NewReader(params.Encode())
-
-	req, err := http.NewRequest("POST", (cl.BaseURI + "token"), body)
-	if err != nil {
-		fmt.Printf("\nLogin failed @ NewRequest: %v", err)
-		return false
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		fmt.Printf("\nLogin failed @ DoRequest: %v", err)
-		return false
-	}
-	defer resp.Body.Close()
-	err = unJson(resp.Body, &cl)
-	if err != nil {
-		fmt.Printf("\nLogin failed @ unJson: %v", err)
-		return false
-	}
-	return true
-}
-
-// validateToken checks if the AccessToken is expired or still valid and returns a bool
-func (cl *Client) validateToken() bool {
-	t, err := time.Parse(time.RFC1123, cl.Expires)
-	if err != nil {
-		fmt.Printf("\nCould not parse Timestamp of AccessToken: %v", err)
-		return false
-	}
-	return time.Now().Before(t.Add(time.Minute * -5))
-}
-
-// request creates, enriches and submits a HTTP-Request to the Cherwell Server
-// and unmarshales the HTTP-Response to a given Output-Object
-func (cl *Client) request(method, uri string, input, output interface{}) {
-	if !cl.validateToken() {
-		if !cl.keepAlive() {
-			cl = cl.Login()
-		}
-	}
-
-	req := &http.Request{}
-	var err error
-
-	if input == nil {
-		params := url.Values{}
-		params.Add("client_id", cl.ClientID)
-		body := strings.NewReader(params.Encode())
-		req, err = http.NewRequest(strings.ToUpper(method), uri, body)
-		if err != nil {
-			fmt.Printf("\nFailed to create Request: %v\nMethod: %v\nURI: %v", err, method, uri)
-			return
-		}
-	} else {
-		payloadBytes, err := json.Marshal(input)
-		if err != nil {
-			fmt.Printf("\nFailed to get BusinessObjectTemplate @ Marshal: %v", err)
-			return
-		}
-		body := bytes.NewReader(payloadBytes)
-
-		req, err = http.NewRequest(strings.ToUpper(method), uri, body)
-		if err != nil {
-			fmt.Printf("\nFailed to create Request: %v\nMethod: %v\nURI: %v", err, method, uri)
-			return
-		}
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", ("Bearer " + cl.Access_token))
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		fmt.Printf("\nFailed to send Request: %v\nMethod: %v\nURI: %v", err, method, uri)
-		return
-	}
-	defer resp.Body.Close()
-
-	err = unJson(resp.Body, &output)
-	if err != nil {
-		fmt.Printf("\nFailed to unmarshal Response: %v\nMethod: %v\nURI: %v\nResponse: %v", err, method, uri, resp)
-		return
-	}
-}
-
-// GetBusinessObjectByDisplayName retreives a Cherwell BusinessObject by given DisplayName and returns it
-func (cl *Client) GetBusinessObjectByDisplayName(displayName string) *BusinessObject {
-	res := []BusinessObject{}
-	uri := cl.BaseURI + getBusObSummariesAllURI
-	cl.request("get", uri, nil, &res)
-	for _, b := range res {
-		//fmt.Printf("\nBusOB: %v", b.DisplayName)
-		if b.DisplayName == displayName {
-			return &b
-		}
-		for _, c := range b.GroupSummaries {
-			if c.DisplayName == displayName {
-				return &c
-			}
-		}
-	}
-
-	return nil
-}
-
-// GetBusinessObjectByBusObID retreives a Cherwell BusinessObject by given BusObID and returns it
-func (cl *Client) GetBusinessObjectByBusObID(busObID string) *BusinessObject {
-	res := []BusinessObject{}
-	uri := cl.BaseURI + getBusObSummariesAllURI
-
-	val := make(map[string]string)
-	val["busobid"] = busObID
-
-	uri = formatURI(uri, val)
-	cl.request("GET", uri, nil, &res)
-
-	for _, b := range res {
-		if b.BusObID == busObID {
-			return &b
-		}
-		for _, c := range b.GroupSummaries {
-			if c.BusObID == busObID {
-				return &c
-			}
-		}
-	}
-	return nil
-}
-
-// GetBusinessObjectRecordByPublicID retreives a Cherwell BusinessObjectRecord by given PublicID and returns it
-func (bo *BusinessObject) GetBusinessObjectRecordByPublicID(cl *Client, publicID string) *BusinessObjectRecord {
-	if bo == nil {
-		fmt.Printf("\nBusinessObject cannot be nil")
-		return nil
-	}
-	res := BusinessObjectRecord{}
-	uri := cl.BaseURI + getBusObRecByPublicIdURI
-
-	val := make(map[string]string)
-	val["busobid"] = bo.BusObID
-	val["busobpublicid"] = publicID
-
-	uri = formatURI(uri, val)
-	cl.request("GET", uri, nil, &res)
-
-	rec := *res.processFields()
-	return &rec
-}
-
-// GetBusinessObjectRecordByRecID retreives a Cherwell BusinessObjectRecord by given RecID and returns it
-func (bo *BusinessObject) GetBusinessObjectRecordByRecID(cl *Client, recID string) *BusinessObjectRecord {
-	if bo == nil {
-		fmt.Printf("\nBusinessObject cannot be nil")
-		return nil
-	}
-	res := BusinessObjectRecord{}
-	uri := cl.BaseURI + getBusObRecByRecIdURI
-
-	val := make(map[string]string)
-	val["busobid"] = bo.BusObID
-	val["busobrecid"] = recID
-
-	uri = formatURI(uri, val)
-
-	cl.request("GET", uri, nil, &res)
-	rec := *res.processFields()
-	return &rec
-}
-
-// NewBusinessObjectRecord creates and saves a Cherwell BusinessObjectRecord with the given fields and returns it
-func (bo *BusinessObject) NewBusinessObjectRecord(cl *Client, fields []Field) *BusinessObjectRecord {
-	if bo == nil {
-		fmt.Printf("\nBusinessObject cannot be nil")
-		return nil
-	}
-	rec := BusinessObjectRecord{}
-	rec.BusObID = bo.BusObID
-	rec.FieldValues = make(map[string]interface{})
-	for _, f := range fields {
-		rec.FieldValues[f.DisplayName] = f.Value
-	}
-
-	templ := bo.getBusinessObjectTemplate(cl)
-	rec.Fields = append(rec.Fields, templ.Fields...)
-
-	res := rec.SaveBusinessObjectRecord(cl)
-
-	return res
-}
-
-// getBusinessObjectTemplate retreives a Cherwell BusinessObjectTemplate of a given BusinessObject and returns it
-func (bo *BusinessObject) getBusinessObjectTemplate(cl *Client) *BusinessObjectTemplate {
-	if bo == nil {
-		fmt.Printf("\nBusinessObject cannot be nil")
-		return nil
-	}
-	res := BusinessObjectTemplate{}
-	uri := cl.BaseURI + getBusObTemplateURI
-	query := struct {
-		BusObID         string `json:"busObId"`
-		IncludeAll      bool   `json:"includeAll"`
-		IncludeRequired bool   `json:"includeRequired"`
-	}{
-		BusObID:         bo.BusObID,
-		IncludeAll:      true,
-		IncludeRequired: true,
-	}
-	cl.request("POST", uri, &query, &res)
-	return &res
-}
-
-// SearchBusinessObjectRecord retreives a Cherwell BusinessObjectRecord by Search-Request with Filters and returns it
-func (bo *BusinessObject) SearchBusinessObjectRecord(cl *Client, filters ...[]string) *BusinessObjectRecord {
-	if bo == nil {
-		fmt.Printf("\nBusinessObject cannot be nil")
-		return nil
-	}
-	res := SearchResult{}
-	uri := cl.BaseURI + getSearchResultsURI
-	filter := []Filter{}
-	fields := bo.getBusinessObjectTemplate(cl)
-	for _, f := range fields.Fields {
-
-		for _, fi := range filters {
-			if len(fi) != 3 {
-				fmt.Printf("\nFilter invalid!\nWant: ['FieldDisplayName','Operator','Value']\nGot:%v", f)
-				return nil
-			}
-			if f.DisplayName == fi[0] {
-				filter = append(filter, Filter{
-					FieldID:  f.FieldID,
-					Operator: fi[1],
-					Value:    fi[2],
-				})
-			}
-		}
-	}
-
-	query := Search{
-		BusObID:          bo.BusObID,
-		Filters:          filter,
-		IncludeAllFields: true,
-	}
-	cl.request("POST", uri, &query, &res)
-	rec := res.BusinessObjects[0].processFields()
-	return rec
-}
-
-// SearchMultipleBusinessObjectRecord retreives multiple Cherwell BusinessObjectRecords by Search-Request with Filters and returns them
-func (bo *BusinessObject) SearchMultipleBusinessObjectRecords(cl *Client, filters ...[]string) *[]BusinessObjectRecord {
-	if bo == nil {
-		fmt.Printf("\nBusinessObject cannot be nil")
-		return nil
-	}
-	res := SearchResult{}
-	uri := cl.BaseURI + getSearchResultsURI
-	filter := []Filter{}
-	fields := bo.getBusinessObjectTemplate(cl)
-	for _, f := range fields.Fields {
-
-		for _, fi := range filters {
-			if len(fi) != 3 {
-				fmt.Printf("\nFilter invalid!\nWant: ['FieldDisplayName','Operator','Value']\nGot:%v", f)
-				return nil
-			}
-			if f.DisplayName == fi[0] {
-				filter = append(filter, Filter{
-					FieldID:  f.FieldID,
-					Operator: fi[1],
-					Value:    fi[2],
-				})
-			}
-		}
-	}
-
-	query := Search{
-		BusObID:          bo.BusObID,
-		Filters:          filter,
-		IncludeAllFields: true,
-	}
-	cl.request("POST", uri, &query, &res)
-	var records []BusinessObjectRecord
-	for _, r := range res.BusinessObjects {
-		records = append(records, *r.processFields())
-	}
-	return &records
-}
-
-// GetBusinessObjectSchema retreives a Cherwell BusinessObjectSchema of a given BusinessObject and returns it
-func (bo *BusinessObject) GetBusinessObjectSchema(cl *Client) *BusinessObjectSchema {
-	if bo == nil {
-		fmt.Printf("\nBusinessObject cannot be nil")
-		return nil
-	}
-	var schema BusinessObjectSchema
-	uri := cl.BaseURI + strings.Replace(getBusObSchemaURI, "$", bo.BusObID, 1)
-	cl.request("GET", uri, nil, &schema)
-	return &schema
-}
-
-// processFields enriches a BusinessObjectRecord with FieldValues
-// to make access to the Values of Fields easier and returns it
-func (rec *BusinessObjectRecord) processFields() *BusinessObjectRecord {
-	fields := make(map[string]interface{})
-	for _, f := range rec.Fields {
-		fields[f.DisplayName] = f.Value
-	}
-	rec.FieldValues = fields
-	return rec
-}
-
-// SaveBusinessObjectRecord commits the Changes in FieldValues to Fields and saves the Cherwell BusinessObjectRecord and returns it
-func (rec *BusinessObjectRecord) SaveBusinessObjectRecord(cl *Client) *BusinessObjectRecord {
-	if rec == nil {
-		fmt.Printf("\nBusinessObjectRecord cannot be nil")
-		return nil
-	}
-	saveResp := BusinessObjectRecord{}
-	uri := cl.BaseURI + saveBusObRecURI
-
-	rec.Persist = true
-
-	for i, f := range rec.Fields {
-		if f.Value != rec.FieldValues[f.DisplayName] {
-			rec.Fields[i].Value = fmt.Sprint(rec.FieldValues[f.DisplayName])
-			rec.Fields[i].Dirty = true
-			fmt.Printf("\nChanged: %v", rec.Fields[i])
-		}
-	}
-	cl.request("post", uri, &rec, &saveResp)
-	return &saveResp
-}
-
-// DeleteBusinessObjectRecord deletes a Cherwell BusinessObjectRecord and returns the Response
-func (rec *BusinessObjectRecord) DeleteBusinesObjectRecord(cl *Client) *BusinessObjectRecord {
-	if rec == nil {
-		fmt.Printf("\nBusinessObjectRecord cannot be nil")
-		return nil
-	}
-	res := BusinessObjectRecord{}
-	uri := cl.BaseURI + deleteBusObRecURI
-	val := make(map[string]string)
-	val["busobid"] = rec.BusObID
-	val["busobrecid"] = rec.BusObRecID
-	uri = formatURI(uri, val)
-	cl.request("DELETE", uri, nil, &res)
-	return &res
-}
-
-// GetRelatedBusinessObjects retreives all Cherwell BusinessObjectRecords, by Name of the Relationship,
-// related to a given BusinessObjectRecord and returns them
-func (rec *BusinessObjectRecord) GetRelatedBusinessObjects(cl *Client, relationshipName string) *[]BusinessObjectRecord {
-	if rec == nil {
-		fmt.Printf("\nBusinessObjectRecord cannot be nil")
-		return nil
-	}
-	res := RelatedBusinessObjects{}
-	uri := cl.BaseURI + getRelatedBusObURI
-	relID := cl.GetBusinessObjectByBusObID(rec.BusObID).GetBusinessObjectSchema(cl).GetRelationshipID(relationshipName)
-
-	val := make(map[string]string)
-	val["busobid"] = rec.BusObID
-	val["busobrecid"] = rec.BusObRecID
-	val["relationshipid"] = relID
-	uri = formatURI(uri, val)
-	cl.request("GET", uri, nil, &res)
-
-	records := []BusinessObjectRecord{}
-
-	for _, r := range res.RelatedBusinessObjects {
-		records = append(records, *r.processFields())
-	}
-	return &records
-}
-
-// LinkBusinessObjectRecord links the Cherwell BusinessObjectRecord to a given Child BusinessObjectRecord
-func (rec *BusinessObjectRecord) LinkBusinessObjectRecord(cl *Client, childRec *BusinessObjectRecord, relationshipName string) *Error {
-	if rec == nil {
-		fmt.Printf("\nBusinessObjectRecord cannot be nil")
-		return nil
-	}
-	res := Error{}
-	busOb := cl.GetBusinessObjectByBusObID(rec.BusObID)
-	if busOb == nil {
-		fmt.Printf("\nNot found: BusinessObject: %v", rec.BusObID)
-		return nil
-	}
-
-	sch := busOb.GetBusinessObjectSchema(cl)
-	if sch == nil {
-		fmt.Printf("\nNot found: BusinessObjectSchema: %v", busOb.DisplayName)
-		return nil
-	}
-	relID := sch.GetRelationshipID(relationshipName)
-	uri := cl.BaseURI + linkBusObRecURI
-
-	val := make(map[string]string)
-	val["busobid"] = rec.BusObID
-	val["busobrecid"] = rec.BusObRecID
-	val["relationshipid"] = relID
-	val["childbusobid"] = childRec.BusObID
-	val["childbusobrecid"] = childRec.BusObRecID
-
-	uri = formatURI(uri, val)
-	cl.request("GET", uri, nil, &res)
-	return &res
-}
-
-// UnlinkBusinessObjectRecord unlinks the Cherwell BusinessObjectRecord from a given Child BusinessObjectRecord
-func (rec *BusinessObjectRecord) UnlinkBusinessObjectRecord(cl *Client, childRec *BusinessObjectRecord, relationshipName string) *Error {
-	if rec == nil {
-		fmt.Printf("\nBusinessObjectRecord cannot be nil")
-		return nil
-	}
-	res := Error{}
-	busOb := cl.GetBusinessObjectByBusObID(rec.BusObID)
-	if busOb == nil {
-		fmt.Printf("\nNot found: BusinessObject: %v", rec.BusObID)
-		return nil
-	}
-
-	sch := busOb.GetBusinessObjectSchema(cl)
-	if sch == nil {
-		fmt.Printf("\nNot found: BusinessObjectSchema: %v", busOb.DisplayName)
-		return nil
-	}
-	relID := sch.GetRelationshipID(relationshipName)
-	uri := cl.BaseURI + unlinkBusObRecURI
-
-	val := make(map[string]string)
-	val["busobid"] = rec.BusObID
-	val["busobrecid"] = rec.BusObRecID
-	val["relationshipid"] = relID
-	val["childbusobid"] = childRec.BusObID
-	val["childbusobrecid"] = childRec.BusObRecID
-
-	uri = formatURI(uri, val)
-	cl.request("DELETE", uri, nil, &res)
-	return &res
-}
-
-// GetRelationshipID links the Cherwell BusinessObjectRecord to a given Child BusinessObjectRecord
-func (sch *BusinessObjectSchema) GetRelationshipID(relationshipName string) string {
-	if sch == nil {
-		fmt.Printf("\nBusinessObjectSchema cannot be nil")
-		return ""
-	}
-	var relID string
-	for _, r := range sch.Relationships {
-		if r.DisplayName == relationshipName {
-			relID = r.RelationshipID
-			return relID
-		}
-	}
-	return relID
-}
-
-// GetTeamMembers retreives all Contacts linked to a Cherwell BusinessObjectRecord of the
-// BusinessObject "OrganizationalUnit" with the Type "Team" and the given Name as Name
-func (cl *Client) GetTeamMembers(teamName string) *[]BusinessObjectRecord {
-	var teamRecID string
-	bo := cl.GetBusinessObjectByDisplayName("Organisationseinheit")
-	teams := bo.SearchMultipleBusinessObjectRecords(cl, []string{
-		"Typ", "EQ", "Team"},
-		[]string{"Voller Name", "EQ", teamName},
-	)
-
-	for _, t := range *teams {
-		if t.BusObPublicID == teamName {
-			teamRecID = t.BusObRecID
-		}
-		fmt.Printf("\nTeam: %v | RecID: %v", t.BusObPublicID, t.BusObRecID)
-	}
-
-	members := bo.GetBusinessObjectRecordByRecID(cl, teamRecID).GetRelatedBusinessObjects(cl, "Organisation Unit Links Contacts Member")
-	return members
-}
-
-// unJson unmarshals a given io.ReadCloser to a given interface
-func unJson(input io.ReadCloser, data interface{}) error {
-	ioBody, err := ioutil.ReadAll(input)
-	if err != nil {
-		fmt.Print(err.Error())
-		return err
-	}
-	ioBody = bytes.TrimPrefix(ioBody, []byte("\xef\xbb\xbf"))
-	body := []byte(string(ioBody[:]))
-	err = json.Unmarshal([]byte(string(body)), &data)
-	return err
-}
-
-// formatURI replaces the Placeholders in a given URI with the given Values
-func formatURI(uri string, values map[string]string) string {
-	if val, ok := values["busobid"]; ok {
-		uri = strings.Replace(uri, busobid, val, 1)
-	}
-	if val, ok := values["busobrecid"]; ok {
-		uri = strings.Replace(uri, busobrecid, val, 1)
-	}
-	if val, ok := values["busobpublicid"]; ok {
-		uri = strings.Replace(uri, busobpublicid, val, 1)
-	}
-	if val, ok := values["childbusobid"]; ok {
-		uri = strings.Replace(uri, childbusobid, val, 1)
-	}
-	if val, ok := values["childbusobrecid"]; ok {
-		uri = strings.Replace(uri, childbusobrecid, val, 1)
-	}
-	if val, ok := values["relationshipid"]; ok {
-		uri = strings.Replace(uri, relationshipid, val, 1)
-	}
-	return uri
-}
+// gocherwell is a go-Module for communication with the REST-API of Cherwell
+package gocherwell
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Cherwell API URIs.
+const (
+	deleteBusObRecURI        = "api/V1/deletebusinessobject/busobid/$/busobrecid/#"
+	saveBusObRecURI          = "api/V1/savebusinessobject"
+	getSearchResultsURI      = "api/V1/getsearchresults"
+	getBusObTemplateURI      = "api/V1/getbusinessobjecttemplate"
+	getQuickSearchResultsURI = "api/V1/getquicksearchresults"
+	getBusObRecByRecIdURI    = "api/v1/getbusinessobject/busobid/$/busobrecid/#"
+	getBusObRecByPublicIdURI = "api/v1/getbusinessobject/busobid/$/publicid/*"
+	getBusObSchemaURI        = "api/v1/getbusinessobjectschema/busobid/$?includerelationships=true"
+	getBusObSummariesURI     = "api/V1/getbusinessobjectsummaries/type/!"
+	getBusObSummaryByIDURI   = "api/V1/getbusinessobjectsummary/busobid/$"
+	getBusObSummaryByNameURI = "api/V1/getbusinessobjectsummary/busobname/~"
+	getRelatedBusObURI       = "api/V1/getrelatedbusinessobject/parentbusobid/$/parentbusobrecid/#/relationshipid/?"
+	linkBusObRecURI          = "api/V2/linkrelatedbusinessobject/parentbusobid/$/parentbusobrecid/#/relationshipid/?/busobid/&/busobrecid/+"
+	unlinkBusObRecURI        = "api/V1/unlinkrelatedbusinessobject/parentbusobid/$/parentbusobrecid/#/relationshipid/?/busobid/&/busobrecid/+"
+	logoutURI                = "api/V1/logout"
+	tokenURI                 = "token"
+	getSearchItemsURI        = "api/V1/getsearchitems"
+	getSearchItemsByBusObURI = "api/V1/getsearchitems/association/$"
+)
+
+// Mapping of the Placeholders for the Cherwell API URIs.
+const (
+	busobid         = "$"
+	busobrecid      = "#"
+	busobpublicid   = "*"
+	relationshipid  = "?"
+	childbusobid    = "&"
+	childbusobrecid = "+"
+	busobname       = "~"
+	busobtype       = "!"
+)
+
+// Client contains the necessary Values to communicate with the Cherwell API.
+type Client struct {
+	User       string `json:"username"`
+	Password   string `json:"password,omitempty"`
+	ClientID   string `json:"as:client_id"`
+	BaseURI    string `json:"-"`
+	Accept     string `json:"Accept,omitempty"`
+	Grant_Type string `json:"grant_type,omitempty"`
+	Auth_mode  string `json:"-"`
+
+	httpClient *http.Client
+	tokens     tokenManager
+	source     TokenSource
+	retry      *RetryPolicy
+	limiter    *limiter
+	logger     Logger
+	hooks      Hooks
+	cache      *MetadataCache
+	team       TeamSettings
+}
+
+// BusinessObject contains the Values of a Cherwell BusinessObject.
+type BusinessObject struct {
+	BusObID         string           `json:"busObId,omitempty"`
+	Name            string           `json:"name,omitempty"`
+	DisplayName     string           `json:"displayName,omitempty"`
+	FirstRecIdField string           `json:"firstRecIdField,omitempty"`
+	GroupSummaries  []BusinessObject `json:"groupSummaries,omitempty"`
+	RecIdFields     string           `json:"recIdFields,omitempty"`
+	StateFieldId    string           `json:"stateFieldId,omitempty"`
+	States          string           `json:"states,omitempty"`
+	Group           bool             `json:"group,omitempty"`
+	Lookup          bool             `json:"lookup,omitempty"`
+	Major           bool             `json:"major,omitempty"`
+	Supporting      bool             `json:"supporting,omitempty"`
+}
+
+// BusinessObject contains the Values of a Cherwell BusinessObjectSchema.
+type BusinessObjectSchema struct {
+	BusObID string `json:"busObId"`
+	Error
+	FieldDefinitions []FieldDefinition `json:"fieldDefinitions"`
+	FirstRecIDField  string            `json:"firstRecIdField"`
+	GridDefinitions  []struct {
+		DisplayName string `json:"displayName"`
+		GridID      string `json:"gridId"`
+		Name        string `json:"name"`
+	} `json:"gridDefinitions"`
+	HTTPStatusCode string         `json:"httpStatusCode"`
+	Name           string         `json:"name"`
+	RecIDFields    string         `json:"recIdFields"`
+	Relationships  []Relationship `json:"relationships"`
+	StateFieldID   string         `json:"stateFieldId"`
+	States         string         `json:"states"`
+}
+
+// FieldDefinition contains the Definition of a Field of a Cherwell BusinessObjectSchema.
+type FieldDefinition struct {
+	AutoFill             bool   `json:"autoFill"`
+	Calculated           bool   `json:"calculated"`
+	Category             string `json:"category"`
+	DecimalDigits        int64  `json:"decimalDigits"`
+	Description          string `json:"description"`
+	Details              string `json:"details"`
+	DisplayName          string `json:"displayName"`
+	Enabled              bool   `json:"enabled"`
+	FieldID              string `json:"fieldId"`
+	HasDate              bool   `json:"hasDate"`
+	HasTime              bool   `json:"hasTime"`
+	IsFullTextSearchable bool   `json:"isFullTextSearchable"`
+	MaximumSize          string `json:"maximumSize"`
+	Name                 string `json:"name"`
+	ReadOnly             bool   `json:"readOnly"`
+	Required             bool   `json:"required"`
+	Type                 string `json:"type"`
+	TypeLocalized        string `json:"typeLocalized"`
+	Validated            bool   `json:"validated"`
+	WholeDigits          int64  `json:"wholeDigits"`
+}
+
+// Relationship contains the Definition of a Relationship of a Cherwell BusinessObjectSchema.
+type Relationship struct {
+	Cardinality      string            `json:"cardinality"`
+	Description      string            `json:"description"`
+	DisplayName      string            `json:"displayName"`
+	FieldDefinitions []FieldDefinition `json:"fieldDefinitions"`
+	RelationshipID   string            `json:"relationshipId"`
+	Target           string            `json:"target"`
+}
+
+// BusinessObject contains the Values of a Cherwell BusinessObjectTemplate.
+type BusinessObjectTemplate struct {
+	Error
+	Fields []Field `json:"fields"`
+}
+
+// BusinessObject contains the Values of a Cherwell BusinessObjectRecord
+// and is used to Unmarshal multiple HTTP-Responses of the Cherwell API.
+type BusinessObjectRecord struct {
+	BusObID               string        `json:"busObId,omitempty"`
+	BusObRecID            string        `json:"busObRecId,omitempty"`
+	BusObPublicID         string        `json:"busObPublicId,omitempty"`
+	CacheKey              string        `json:"cacheKey,omitempty"`
+	CacheScope            string        `json:"cacheScope,omitempty"`
+	Fields                []Field       `json:"fields,omitempty"`
+	Persist               bool          `json:"persist,omitempty"`
+	FieldValidationErrors []interface{} `json:"fieldValidationErrors,omitempty"`
+	Error
+	NotificationTriggers []interface{} `json:"notificationTriggers,omitempty"`
+	Links                []Link        `json:"links,omitempty"`
+	// FieldValues holds the Values of the Fields by DisplayName. On save, Keys may be any FieldID,
+	// FullFieldID, internal Name or DisplayName, so Values can be set independent of the Language.
+	FieldValues map[string]interface{} `json:"-"`
+}
+
+// RelatedBusinessObjects is used to Unmarshal the HTTP-Response of the Cherwell API
+// regarding related BusinessObjects.
+type RelatedBusinessObjects struct {
+	Error
+	Links                  []Link                 `json:"links"`
+	PageNumber             int64                  `json:"pageNumber"`
+	PageSize               int64                  `json:"pageSize"`
+	ParentBusObID          string                 `json:"parentBusObId"`
+	ParentBusObPublicID    string                 `json:"parentBusObPublicId"`
+	ParentBusObRecID       string                 `json:"parentBusObRecId"`
+	RelatedBusinessObjects []BusinessObjectRecord `json:"relatedBusinessObjects"`
+	RelationshipID         string                 `json:"relationshipId"`
+	TotalRecords           int64                  `json:"totalRecords"`
+}
+
+// Search is used to Marshal the HTTP-Request to the Cherwell API
+// regarding Searches.
+type Search struct {
+	Association        string        `json:"association,omitempty"`
+	BusObID            string        `json:"busObId,omitempty"`
+	CustomGridDefID    string        `json:"customGridDefId,omitempty"`
+	DateTimeFormatting string        `json:"dateTimeFormatting,omitempty"`
+	FieldID            string        `json:"fieldId,omitempty"`
+	Fields             []string      `json:"fields,omitempty"`
+	Filters            []Filter      `json:"filters,omitempty"`
+	IncludeAllFields   bool          `json:"includeAllFields,omitempty"`
+	IncludeSchema      bool          `json:"includeSchema,omitempty"`
+	PageNumber         int64         `json:"pageNumber,omitempty"`
+	PageSize           int64         `json:"pageSize,omitempty"`
+	PromptValues       []PromptValue `json:"promptValues,omitempty"`
+	Scope              string        `json:"scope,omitempty"`
+	ScopeOwner         string        `json:"scopeOwner,omitempty"`
+	SearchID           string        `json:"searchId,omitempty"`
+	SearchName         string        `json:"searchName,omitempty"`
+	SearchText         string        `json:"searchText,omitempty"`
+	Sorting            []Sort        `json:"sorting,omitempty"`
+}
+
+// PromptValue contains the Value of a Prompt of a stored Search.
+type PromptValue struct {
+	BusObID                  string      `json:"busObId,omitempty"`
+	CollectionStoreEntireRow string      `json:"collectionStoreEntireRow,omitempty"`
+	CollectionValueField     string      `json:"collectionValueField,omitempty"`
+	FieldID                  string      `json:"fieldId,omitempty"`
+	ListReturnFieldID        string      `json:"listReturnFieldId,omitempty"`
+	PromptID                 string      `json:"promptId,omitempty"`
+	Value                    interface{} `json:"value,omitempty"`
+	ValueIsRecID             bool        `json:"valueIsRecId,omitempty"`
+}
+
+// Sort contains the Field and Direction to sort the Results of a Search by.
+type Sort struct {
+	FieldID       string `json:"fieldId,omitempty"`
+	SortDirection int64  `json:"sortDirection,omitempty"`
+}
+
+// SearchResult is used to Unmarshal the HTTP-Response of the Cherwell API
+// regarding Searches.
+type SearchResult struct {
+	BusinessObjects []BusinessObjectRecord `json:"businessObjects,omitempty"`
+	Error
+	HasPrompts          bool     `json:"hasPrompts,omitempty"`
+	Links               []Link   `json:"links,omitempty"`
+	Prompts             []Prompt `json:"prompts,omitempty"`
+	SearchResultsFields []struct {
+		Caption                   string `json:"caption,omitempty"`
+		CurrencyCulture           string `json:"currencyCulture,omitempty"`
+		CurrencySymbol            string `json:"currencySymbol,omitempty"`
+		DecimalDigits             int64  `json:"decimalDigits,omitempty"`
+		DefaultSortOrderAscending bool   `json:"defaultSortOrderAscending,omitempty"`
+		DisplayName               string `json:"displayName,omitempty"`
+		FieldID                   string `json:"fieldId,omitempty"`
+		FieldName                 string `json:"fieldName,omitempty"`
+		FullFieldID               string `json:"fullFieldId,omitempty"`
+		HasDefaultSortField       bool   `json:"hasDefaultSortField,omitempty"`
+		IsBinary                  bool   `json:"isBinary,omitempty"`
+		IsCurrency                bool   `json:"isCurrency,omitempty"`
+		IsDateTime                bool   `json:"isDateTime,omitempty"`
+		IsFilterAllowed           bool   `json:"isFilterAllowed,omitempty"`
+		IsLogical                 bool   `json:"isLogical,omitempty"`
+		IsNumber                  bool   `json:"isNumber,omitempty"`
+		IsShortDate               bool   `json:"isShortDate,omitempty"`
+		IsShortTime               bool   `json:"isShortTime,omitempty"`
+		IsVisible                 bool   `json:"isVisible,omitempty"`
+		SortOrder                 string `json:"sortOrder,omitempty"`
+		Sortable                  bool   `json:"sortable,omitempty"`
+		StorageName               string `json:"storageName,omitempty"`
+		WholeDigits               int64  `json:"wholeDigits,omitempty"`
+	} `json:"searchResultsFields,omitempty"`
+	SimpleResults SimpleResults `json:"simpleResults,omitempty"`
+	TotalRows     int64         `json:"totalRows,omitempty"`
+}
+
+// Prompt contains the Definition of a Prompt of a stored Search, which asks for a Value before the Search is run.
+type Prompt struct {
+	AllowValuesOnly          bool        `json:"allowValuesOnly,omitempty"`
+	BusObID                  string      `json:"busObId,omitempty"`
+	CollectionStoreEntireRow string      `json:"collectionStoreEntireRow,omitempty"`
+	CollectionValueField     string      `json:"collectionValueField,omitempty"`
+	ConstraintXML            string      `json:"constraintXml,omitempty"`
+	Contents                 string      `json:"contents,omitempty"`
+	Default                  string      `json:"default,omitempty"`
+	FieldID                  string      `json:"fieldId,omitempty"`
+	IsDateRange              bool        `json:"isDateRange,omitempty"`
+	ListDisplayOption        string      `json:"listDisplayOption,omitempty"`
+	ListReturnFieldID        string      `json:"listReturnFieldId,omitempty"`
+	MultiLine                bool        `json:"multiLine,omitempty"`
+	PromptID                 string      `json:"promptId,omitempty"`
+	PromptType               string      `json:"promptType,omitempty"`
+	PromptTypeName           string      `json:"promptTypeName,omitempty"`
+	Required                 bool        `json:"required,omitempty"`
+	Text                     string      `json:"text,omitempty"`
+	Value                    interface{} `json:"value,omitempty"`
+	Values                   []string    `json:"values,omitempty"`
+}
+
+// SimpleResults contains the Results of a QuickSearch grouped by BusinessObject.
+type SimpleResults struct {
+	Error
+	Groups []SimpleResultsGroup `json:"groups,omitempty"`
+	Title  string               `json:"title,omitempty"`
+}
+
+// SimpleResultsGroup contains the Results of a QuickSearch in a single BusinessObject.
+// If IsBusObTarget is set, TargetID is the BusObID of the BusinessObject.
+type SimpleResultsGroup struct {
+	Error
+	IsBusObTarget          bool                    `json:"isBusObTarget,omitempty"`
+	SimpleResultsListItems []SimpleResultsListItem `json:"simpleResultsListItems,omitempty"`
+	SubTitle               string                  `json:"subTitle,omitempty"`
+	TargetID               string                  `json:"targetId,omitempty"`
+	Title                  string                  `json:"title,omitempty"`
+}
+
+// SimplehResultsListItem is used to Unmarshal multiple HTTP-Responses of the Cherwell API
+// regarding Searches.
+// Extends SearchResult
+type SimpleResultsListItem struct {
+	BusinessObjectRecord
+	DocRepositoryItemID string `json:"docRepositoryItemId,omitempty"`
+	GalleryImage        string `json:"galleryImage,omitempty"`
+	PublicID            string `json:"publicId,omitempty"`
+	Scope               string `json:"scope,omitempty"`
+	ScopeOwner          string `json:"scopeOwner,omitempty"`
+	SubTitle            string `json:"subTitle,omitempty"`
+	Text                string `json:"text,omitempty"`
+	Title               string `json:"title,omitempty"`
+}
+
+// Filter is used to Marshal the HTTP-Response to the Cherwell API
+// regarding Searches.
+// Extends Search
+type Filter struct {
+	FieldID   string `json:"fieldId,omitempty"`
+	FieldName string `json:"-"`
+	Operator  string `json:"operator,omitempty"`
+	Value     string `json:"value,omitempty"`
+}
+
+// Field is used to Marshal the HTTP-Response to the Cherwell API.
+// Extends multiple Types
+type Field struct {
+	Dirty       bool   `json:"dirty,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	Html        string `json:"html,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Value       string `json:"value,omitempty"`
+	FieldID     string `json:"fieldId,omitempty"`
+	FullFieldID string `json:"fullFieldId"`
+}
+
+// Error is used to Marshal the HTTP-Response to the Cherwell API.
+// Extends multiple Types
+type Error struct {
+	ErrorCode      string `json:"errorCode,omitempty"`
+	ErrorMessage   string `json:"errorMessage,omitempty"`
+	HasError       bool   `json:"hasError,omitempty"`
+	HTTPStatusCode string `json:"httpStatusCode"`
+}
+
+// Link is used to Marshal the HTTP-Response to the Cherwell API.
+// Extends multiple Types
+type Link struct {
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+// NewClient returns a Pointer to an Instance of a Cherwell Client configured by the given Options
+func NewClient(user, password, clientID, baseURI, auth_mode, grant_type string, opts ...Option) *Client {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.logger != nil {
+		o.logger = redactingLogger{o.logger}
+	}
+	return &Client{
+		User:       user,
+		Password:   password,
+		ClientID:   clientID,
+		BaseURI:    baseURI,
+		Auth_mode:  auth_mode,
+		Grant_Type: grant_type,
+		httpClient: o.buildHTTPClient(),
+		source:     o.tokenSource,
+		retry:      o.retryPolicy,
+		limiter:    newLimiter(o.rateLimit, o.burst, o.maxInFlight),
+		logger:     o.logger,
+		hooks:      o.hooks(),
+		cache:      o.metadataCache,
+		team:       o.teamSettings,
+	}
+}
+
+// client returns the http.Client of the Client or http.DefaultClient if the Client was not created by NewClient
+func (cl *Client) client() *http.Client {
+	if cl.httpClient == nil {
+		return http.DefaultClient
+	}
+	return cl.httpClient
+}
+
+// Login authenticates with the Cherwell Server using the TokenSource of the Client and retreives an AccessToken, saves it
+// in the Instance of the Client and returns a Pointer to the Instance of the Client
+func (cl *Client) Login(ctx context.Context) (*Client, error) {
+	if cl.tokens.isClosed() {
+		return nil, ErrClientClosed
+	}
+	t, err := cl.tokenSource().Token(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+	cl.tokens.set(t)
+	return cl, nil
+}
+
+// tokenSource returns the TokenSource of the Client or PasswordGrant if none is set
+func (cl *Client) tokenSource() TokenSource {
+	if cl.source == nil {
+		return PasswordGrant{}
+	}
+	return cl.source
+}
+
+// passwordGrant authenticates with the Cherwell Server using the configured Grant_Type and returns the Token
+func (cl *Client) passwordGrant(ctx context.Context) (*Token, error) {
+	params := url.Values{}
+	params.Add("grant_type", cl.Grant_Type)
+	params.Add("client_id", cl.ClientID)
+	params.Add("username", cl.User)
+	if cl.Password != "" {
+		params.Add("password", cl.Password)
+	}
+	t, err := cl.tokenRequest(ctx, cl.BaseURI+tokenURI+"?auth_mode="+cl.Auth_mode, params)
+	if err != nil {
+		return nil, fmt.Errorf("login failed: %w", err)
+	}
+	return t, nil
+}
+
+// keepAlive sends the RefreshToken to the Cherwell Server and retreives a new AccessToken
+func (cl *Client) keepAlive(ctx context.Context, refreshToken string) (*Token, error) {
+	params := url.Values{}
+	params.Add("grant_type", "refresh_token")
+	params.Add("client_id", cl.ClientID)
+	params.Add("refresh_token", refreshToken)
+	t, err := cl.tokenRequest(ctx, cl.BaseURI+tokenURI, params)
+	if err != nil {
+		return nil, fmt.Errorf("refresh failed: %w", err)
+	}
+	return t, nil
+}
+
+// tokenRequest sends the given Parameters to the token endpoint and returns the granted Token.
+// Like every other Request it passes the Hooks, the Limiter and the RetryPolicy of the Client,
+// but rejected Grants are never retried
+func (cl *Client) tokenRequest(ctx context.Context, uri string, params url.Values) (*Token, error) {
+	info := RequestInfo{Method: http.MethodPost, Endpoint: tokenURI, URI: uri}
+	t := Token{}
+	err := cl.do(ctx, info, false, func(ctx context.Context, _ string, tr *transfer) (*http.Response, error) {
+		return cl.sendForm(ctx, uri, params, &t, tr)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// sendForm submits the Parameters form-encoded to the token endpoint of the Cherwell Server and
+// unmarshales the HTTP-Response to the given Token. The returned Response is already closed.
+func (cl *Client) sendForm(ctx context.Context, uri string, params url.Values, output *Token, tr *transfer) (*http.Response, error) {
+	payload := params.Encode()
+	tr.RequestBytes = int64(len(payload))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, strings.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Request: %w (Method: POST, URI: %v)", err, uri)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := cl.client().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send Request: %w (Method: POST, URI: %v)", err, uri)
+	}
+	defer resp.Body.Close()
+	resp.Body = countingReader{ReadCloser: resp.Body, n: &tr.ResponseBytes}
+	if resp.StatusCode >= 400 {
+		return resp, tokenError("POST", uri, resp)
+	}
+	if err := unJson(resp.Body, output); err != nil {
+		return resp, fmt.Errorf("failed to unmarshal Response: %w (Method: POST, URI: %v, Status: %v)", err, uri, resp.Status)
+	}
+	return resp, nil
+}
+
+// tokenError converts the OAuth-Error of the token endpoint to an APIError
+func tokenError(method, uri string, resp *http.Response) error {
+	res := struct {
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}{}
+	_ = unJson(resp.Body, &res)
+	return newAPIError(method, uri, resp.StatusCode, Error{
+		ErrorCode:    res.Error,
+		ErrorMessage: res.ErrorDescription,
+		HasError:     true,
+	})
+}
+
+// request creates, enriches and submits a HTTP-Request to the Cherwell Server
+// for the given Endpoint with its Placeholders replaced by the given Values
+// and unmarshales the HTTP-Response to a given Output-Object. It re-authenticates once on 401
+// and retries it according to the RetryPolicy of the Client. Every attempt passes the Limiter of the Client.
+func (cl *Client) request(ctx context.Context, method, endpoint string, val map[string]string, input, output interface{}) error {
+	return cl.requestWithToken(ctx, "", method, endpoint, val, input, output)
+}
+
+// requestWithToken sends a Request like request. A given AccessToken is used instead of the Token of the
+// Client and is not renewed on Unauthorized, e.g. to revoke it on Logout
+func (cl *Client) requestWithToken(ctx context.Context, accessToken, method, endpoint string, val map[string]string, input, output interface{}) error {
+	info := RequestInfo{
+		Method:   strings.ToUpper(method),
+		Endpoint: endpoint,
+		URI:      formatURI(cl.BaseURI+endpoint, val),
+		BusObID:  val["busobid"],
+	}
+	return cl.do(ctx, info, accessToken == "", func(ctx context.Context, token string, tr *transfer) (*http.Response, error) {
+		if accessToken != "" {
+			token = accessToken
+		}
+		return cl.send(ctx, info.Method, info.URI, token, input, output, tr)
+	})
+}
+
+// sendFunc sends a single attempt of a Request authorized with the given AccessToken.
+type sendFunc func(ctx context.Context, accessToken string, tr *transfer) (*http.Response, error)
+
+// do sends a Request with send, surrounded by the Hooks of the Client and retried according to its RetryPolicy.
+// With authorize, send gets the AccessToken of the Client, which is renewed once on Unauthorized
+func (cl *Client) do(ctx context.Context, info RequestInfo, authorize bool, send sendFunc) error {
+	res := ResponseInfo{}
+	begin := time.Now()
+	if cl.hooks != nil {
+		ctx = cl.hooks.OnRequestStart(ctx, info)
+		defer func() {
+			res.Duration = time.Since(begin)
+			cl.hooks.OnRequestEnd(ctx, info, res)
+		}()
+	}
+	res.Err = cl.retryRequest(ctx, info, authorize, send, &res)
+	return res.Err
+}
+
+// retryRequest sends the Request until it succeeds or the RetryPolicy gives up and records the Result.
+// Every attempt passes the Limiter of the Client
+func (cl *Client) retryRequest(ctx context.Context, info RequestInfo, authorize bool, send sendFunc, res *ResponseInfo) error {
+	method, uri := info.Method, info.URI
+	policy := cl.retryPolicy()
+	reauthenticated := !authorize
+	for attempt := 1; ; attempt++ {
+		accessToken := ""
+		if authorize {
+			var err error
+			if accessToken, err = cl.accessToken(ctx); err != nil {
+				return err
+			}
+		}
+		release, err := cl.limiter.acquire(ctx)
+		if err != nil {
+			return err
+		}
+		start := time.Now()
+		tr := transfer{}
+		resp, err := send(ctx, accessToken, &tr)
+		release()
+		status := statusCode(resp, err)
+		res.StatusCode = status
+		res.RequestBytes += tr.RequestBytes
+		res.ResponseBytes += tr.ResponseBytes
+		cl.log().Debug("cherwell request", "method", method, "uri", uri,
+			"status", status, "duration", time.Since(start), "attempt", attempt)
+		if err == nil {
+			return nil
+		}
+		if status == http.StatusUnauthorized && !reauthenticated {
+			cl.log().Info("cherwell request unauthorized, re-authenticating", "method", strings.ToUpper(method), "uri", uri)
+			reauthenticated = true
+			cl.tokens.invalidate(accessToken)
+			attempt--
+			res.Retries++
+			continue
+		}
+		if attempt >= policy.MaxAttempts || !policy.Retryable(method, uri, status, err) || grantRejected(info, status) {
+			return err
+		}
+		delay := policy.backoff(attempt, resp)
+		cl.log().Warn("retrying cherwell request", "method", strings.ToUpper(method), "uri", uri,
+			"status", status, "error", err, "attempt", attempt, "delay", delay)
+		if serr := sleep(ctx, delay); serr != nil {
+			return fmt.Errorf("%w: retry aborted after: %v", serr, err)
+		}
+		res.Retries++
+	}
+}
+
+// grantRejected reports whether the token endpoint rejected the Grant, which must not be retried
+func grantRejected(info RequestInfo, status int) bool {
+	return info.Endpoint == tokenURI && status >= 400 && status < 500 && status != http.StatusTooManyRequests
+}
+
+// send submits a HTTP-Request authorized with the given AccessToken to the Cherwell Server
+// and unmarshales the HTTP-Response to a given Output-Object. The returned Response is
+// already closed and only carries Status and Headers.
+func (cl *Client) send(ctx context.Context, method, uri, accessToken string, input, output interface{}, tr *transfer) (*http.Response, error) {
+	if tr == nil {
+		tr = &transfer{}
+	}
+	var payloadBytes []byte
+	if input == nil {
+		params := url.Values{}
+		params.Add("client_id", cl.ClientID)
+		payloadBytes = []byte(params.Encode())
+	} else {
+		var err error
+		payloadBytes, err = json.Marshal(input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal Request: %w (Method: %v, URI: %v)", err, method, uri)
+		}
+	}
+	body := bytes.NewReader(payloadBytes)
+	tr.RequestBytes = int64(len(payloadBytes))
+
+	req, err := http.NewRequestWithContext(ctx, strings.ToUpper(method), uri, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Request: %w (Method: %v, URI: %v)", err, method, uri)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", ("Bearer " + accessToken))
+
+	resp, err := cl.client().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send Request: %w (Method: %v, URI: %v)", err, method, uri)
+	}
+	defer resp.Body.Close()
+	resp.Body = countingReader{ReadCloser: resp.Body, n: &tr.ResponseBytes}
+
+	if resp.StatusCode >= 400 {
+		// On BadRequest the Output-Object is filled as well, as it may carry details like FieldValidationErrors.
+		// Other Errors may be retried and must not leave their Error in the Output-Object.
+		data, _ := ioutil.ReadAll(resp.Body)
+		res := Error{}
+		_ = unJson(ioutil.NopCloser(bytes.NewReader(data)), &res)
+		if _, ok := output.(errorResponse); ok && resp.StatusCode == http.StatusBadRequest {
+			_ = unJson(ioutil.NopCloser(bytes.NewReader(data)), output)
+		}
+		return resp, newAPIError(method, uri, resp.StatusCode, res)
+	}
+
+	err = unJson(resp.Body, &output)
+	if err != nil {
+		return resp, fmt.Errorf("failed to unmarshal Response: %w (Method: %v, URI: %v, Status: %v)", err, method, uri, resp.Status)
+	}
+	if r, ok := output.(errorResponse); ok {
+		if e := r.responseError(); e != nil {
+			return resp, newAPIError(method, uri, resp.StatusCode, *e)
+		}
+	}
+	return resp, nil
+}
+
+// GetBusinessObjectByDisplayName retreives a Cherwell BusinessObject by given DisplayName and returns it
+func (cl *Client) GetBusinessObjectByDisplayName(ctx context.Context, displayName string) (*BusinessObject, error) {
+	res, err := cl.getBusinessObjectSummaries(ctx, BusinessObjectTypeAll)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range res {
+		if b.DisplayName == displayName {
+			return &b, nil
+		}
+		for _, c := range b.GroupSummaries {
+			if c.DisplayName == displayName {
+				return &c, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: BusinessObject: %v", ErrNotFound, displayName)
+}
+
+// GetBusinessObjectByBusObID retreives a Cherwell BusinessObject by given BusObID and returns it
+func (cl *Client) GetBusinessObjectByBusObID(ctx context.Context, busObID string) (*BusinessObject, error) {
+	return cl.getBusinessObjectSummary(ctx, getBusObSummaryByIDURI, busObIDValues(busObID), summaryKey+"id:"+busObID, busObID)
+}
+
+// GetBusinessObjectRecordByPublicID retreives a Cherwell BusinessObjectRecord by given PublicID and returns it
+func (bo *BusinessObject) GetBusinessObjectRecordByPublicID(ctx context.Context, cl *Client, publicID string) (*BusinessObjectRecord, error) {
+	if bo == nil {
+		return nil, fmt.Errorf("%w: BusinessObject", ErrNilReceiver)
+	}
+	res := BusinessObjectRecord{}
+
+	val := make(map[string]string)
+	val["busobid"] = bo.BusObID
+	val["busobpublicid"] = publicID
+
+	if err := cl.request(ctx, "GET", getBusObRecByPublicIdURI, val, nil, &res); err != nil {
+		return nil, err
+	}
+
+	return res.processFields(), nil
+}
+
+// GetBusinessObjectRecordByRecID retreives a Cherwell BusinessObjectRecord by given RecID and returns it
+func (bo *BusinessObject) GetBusinessObjectRecordByRecID(ctx context.Context, cl *Client, recID string) (*BusinessObjectRecord, error) {
+	if bo == nil {
+		return nil, fmt.Errorf("%w: BusinessObject", ErrNilReceiver)
+	}
+	res := BusinessObjectRecord{}
+
+	val := make(map[string]string)
+	val["busobid"] = bo.BusObID
+	val["busobrecid"] = recID
+
+	if err := cl.request(ctx, "GET", getBusObRecByRecIdURI, val, nil, &res); err != nil {
+		return nil, err
+	}
+	return res.processFields(), nil
+}
+
+// NewBusinessObjectRecord creates and saves a Cherwell BusinessObjectRecord with the given fields and returns it
+func (bo *BusinessObject) NewBusinessObjectRecord(ctx context.Context, cl *Client, fields []Field) (*BusinessObjectRecord, error) {
+	if bo == nil {
+		return nil, fmt.Errorf("%w: BusinessObject", ErrNilReceiver)
+	}
+	rec := BusinessObjectRecord{}
+	rec.BusObID = bo.BusObID
+	rec.FieldValues = make(map[string]interface{})
+	for _, f := range fields {
+		rec.FieldValues[fieldKey(f)] = f.Value
+	}
+
+	templ, err := bo.getBusinessObjectTemplate(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+	rec.Fields = append(rec.Fields, templ.Fields...)
+
+	return rec.SaveBusinessObjectRecord(ctx, cl)
+}
+
+// getBusinessObjectTemplate retreives a Cherwell BusinessObjectTemplate of a given BusinessObject and returns it
+func (bo *BusinessObject) getBusinessObjectTemplate(ctx context.Context, cl *Client) (*BusinessObjectTemplate, error) {
+	if bo == nil {
+		return nil, fmt.Errorf("%w: BusinessObject", ErrNilReceiver)
+	}
+	res := BusinessObjectTemplate{}
+	query := struct {
+		BusObID         string `json:"busObId"`
+		IncludeAll      bool   `json:"includeAll"`
+		IncludeRequired bool   `json:"includeRequired"`
+	}{
+		BusObID:         bo.BusObID,
+		IncludeAll:      true,
+		IncludeRequired: true,
+	}
+	err := cl.cached(templateKey+bo.BusObID, bo.BusObID, &res, func() error {
+		return cl.request(ctx, "POST", getBusObTemplateURI, busObIDValues(bo.BusObID), &query, &res)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// SearchBusinessObjectRecord retreives a Cherwell BusinessObjectRecord by Search-Request with Filters and returns it
+func (bo *BusinessObject) SearchBusinessObjectRecord(ctx context.Context, cl *Client, filters ...[]string) (*BusinessObjectRecord, error) {
+	q, err := bo.filterQuery(filters)
+	if err != nil {
+		return nil, err
+	}
+	return q.First(ctx, cl)
+}
+
+// SearchOneBusinessObjectRecord retreives the only Cherwell BusinessObjectRecord matching the Filters and returns it.
+// It returns ErrNotFound if nothing matches and ErrMultipleResults if the Filters match more than one Record.
+func (bo *BusinessObject) SearchOneBusinessObjectRecord(ctx context.Context, cl *Client, filters ...[]string) (*BusinessObjectRecord, error) {
+	q, err := bo.filterQuery(filters)
+	if err != nil {
+		return nil, err
+	}
+	return q.One(ctx, cl)
+}
+
+// SearchMultipleBusinessObjectRecord retreives multiple Cherwell BusinessObjectRecords by Search-Request with Filters and returns them.
+// Every Filter is a Triple ['Field','Operator','Value'], Filters on the same Field are combined with OR.
+func (bo *BusinessObject) SearchMultipleBusinessObjectRecords(ctx context.Context, cl *Client, filters ...[]string) (*[]BusinessObjectRecord, error) {
+	q, err := bo.filterQuery(filters)
+	if err != nil {
+		return nil, err
+	}
+	return q.Records(ctx, cl)
+}
+
+// filterQuery builds a Query from Filters ['Field','Operator','Value'], combining Filters on the same Field with OR
+func (bo *BusinessObject) filterQuery(filters [][]string) (*Query, error) {
+	if bo == nil {
+		return nil, fmt.Errorf("%w: BusinessObject", ErrNilReceiver)
+	}
+	q := bo.NewQuery()
+	q.orSameField = true
+	for _, fi := range filters {
+		if len(fi) != 3 {
+			return nil, fmt.Errorf("%w: Filter invalid: want ['Field','Operator','Value'], got %v", ErrValidation, fi)
+		}
+		q.Where(fi[0]).Is(Operator(fi[1]), fi[2])
+	}
+	return q, nil
+}
+
+// GetBusinessObjectSchema retreives a Cherwell BusinessObjectSchema of a given BusinessObject and returns it
+func (bo *BusinessObject) GetBusinessObjectSchema(ctx context.Context, cl *Client) (*BusinessObjectSchema, error) {
+	if bo == nil {
+		return nil, fmt.Errorf("%w: BusinessObject", ErrNilReceiver)
+	}
+	var schema BusinessObjectSchema
+	err := cl.cached(schemaKey+bo.BusObID, bo.BusObID, &schema, func() error {
+		return cl.request(ctx, "GET", getBusObSchemaURI, busObIDValues(bo.BusObID), nil, &schema)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// fieldKey returns the most specific Identifier of a Field to be used as Key of FieldValues
+func fieldKey(f Field) string {
+	switch {
+	case f.FullFieldID != "":
+		return f.FullFieldID
+	case f.FieldID != "":
+		return f.FieldID
+	case f.Name != "":
+		return f.Name
+	}
+	return f.DisplayName
+}
+
+// processFields enriches a BusinessObjectRecord with FieldValues
+// to make access to the Values of Fields easier and returns it
+func (rec *BusinessObjectRecord) processFields() *BusinessObjectRecord {
+	fields := make(map[string]interface{})
+	for _, f := range rec.Fields {
+		fields[f.DisplayName] = f.Value
+	}
+	rec.FieldValues = fields
+	return rec
+}
+
+// SaveBusinessObjectRecord commits the Changes in FieldValues to Fields and saves the Cherwell BusinessObjectRecord and returns it.
+// The Keys of FieldValues are resolved like Field, Keys without Field return ErrNotFound.
+// Times and Decimals are formatted by the FieldDefinition of the cached BusinessObjectSchema.
+func (rec *BusinessObjectRecord) SaveBusinessObjectRecord(ctx context.Context, cl *Client) (*BusinessObjectRecord, error) {
+	if rec == nil {
+		return nil, fmt.Errorf("%w: BusinessObjectRecord", ErrNilReceiver)
+	}
+	saveResp := BusinessObjectRecord{}
+
+	rec.Persist = true
+
+	// Compare with the original Values, so an unchanged Key does not revert a Field changed by another Key.
+	original := make([]string, len(rec.Fields))
+	for i, f := range rec.Fields {
+		original[i] = f.Value
+	}
+	keys := make([]string, 0, len(rec.FieldValues))
+	for k := range rec.FieldValues {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	// The Schema is only retreived for Values formatted by their FieldDefinition, like Dates and Decimals.
+	var sch *BusinessObjectSchema
+	for _, k := range keys {
+		i := resolveField(rec.Fields, k)
+		if i < 0 {
+			return nil, fmt.Errorf("%w: Field: %v", ErrNotFound, k)
+		}
+		v := rec.FieldValues[k]
+		if sameValue(original[i], v) {
+			continue
+		}
+		var def *FieldDefinition
+		if formatsByDefinition(v) {
+			if sch == nil {
+				var err error
+				if sch, err = (&BusinessObject{BusObID: rec.BusObID}).GetBusinessObjectSchema(ctx, cl); err != nil {
+					return nil, err
+				}
+			}
+			def, _ = sch.FieldDefinition(rec.Fields[i].FieldID)
+		}
+		if value := formatValue(def, v); value != original[i] {
+			rec.Fields[i].Value = value
+			rec.Fields[i].Dirty = true
+			cl.log().Debug("field changed", "busObId", rec.BusObID, "busObRecId", rec.BusObRecID,
+				"field", rec.Fields[i].DisplayName, "value", rec.Fields[i].Value)
+		}
+	}
+	err := cl.request(ctx, "POST", saveBusObRecURI, busObIDValues(rec.BusObID), &rec, &saveResp)
+	if len(saveResp.FieldValidationErrors) > 0 {
+		apiErr := &APIError{}
+		if !errors.As(err, &apiErr) {
+			apiErr = newAPIError("POST", cl.BaseURI+saveBusObRecURI, http.StatusOK, saveResp.Error)
+			err = apiErr
+		}
+		apiErr.FieldValidationErrors = saveResp.FieldValidationErrors
+	}
+	if err != nil {
+		return &saveResp, err
+	}
+	return &saveResp, nil
+}
+
+// DeleteBusinessObjectRecord deletes a Cherwell BusinessObjectRecord and returns the Response
+func (rec *BusinessObjectRecord) DeleteBusinesObjectRecord(ctx context.Context, cl *Client) (*BusinessObjectRecord, error) {
+	if rec == nil {
+		return nil, fmt.Errorf("%w: BusinessObjectRecord", ErrNilReceiver)
+	}
+	res := BusinessObjectRecord{}
+	val := make(map[string]string)
+	val["busobid"] = rec.BusObID
+	val["busobrecid"] = rec.BusObRecID
+	if err := cl.request(ctx, "DELETE", deleteBusObRecURI, val, nil, &res); err != nil {
+		return &res, err
+	}
+	return &res, nil
+}
+
+// relationshipID resolves the ID of the Relationship with the given Name
+// of the BusinessObject the BusinessObjectRecord belongs to
+func (rec *BusinessObjectRecord) relationshipID(ctx context.Context, cl *Client, relationshipName string) (string, error) {
+	busOb := BusinessObject{BusObID: rec.BusObID}
+	sch, err := busOb.GetBusinessObjectSchema(ctx, cl)
+	if err != nil {
+		return "", err
+	}
+	return sch.GetRelationshipID(relationshipName)
+}
+
+// GetRelatedBusinessObjects retreives all Cherwell BusinessObjectRecords, by Name of the Relationship,
+// related to a given BusinessObjectRecord and returns them
+func (rec *BusinessObjectRecord) GetRelatedBusinessObjects(ctx context.Context, cl *Client, relationshipName string) (*[]BusinessObjectRecord, error) {
+	if rec == nil {
+		return nil, fmt.Errorf("%w: BusinessObjectRecord", ErrNilReceiver)
+	}
+	res := RelatedBusinessObjects{}
+	relID, err := rec.relationshipID(ctx, cl, relationshipName)
+	if err != nil {
+		return nil, err
+	}
+
+	val := make(map[string]string)
+	val["busobid"] = rec.BusObID
+	val["busobrecid"] = rec.BusObRecID
+	val["relationshipid"] = relID
+	if err := cl.request(ctx, "GET", getRelatedBusObURI, val, nil, &res); err != nil {
+		return nil, err
+	}
+
+	records := []BusinessObjectRecord{}
+
+	for _, r := range res.RelatedBusinessObjects {
+		records = append(records, *r.processFields())
+	}
+	return &records, nil
+}
+
+// LinkBusinessObjectRecord links the Cherwell BusinessObjectRecord to a given Child BusinessObjectRecord
+func (rec *BusinessObjectRecord) LinkBusinessObjectRecord(ctx context.Context, cl *Client, childRec *BusinessObjectRecord, relationshipName string) error {
+	if rec == nil || childRec == nil {
+		return fmt.Errorf("%w: BusinessObjectRecord", ErrNilReceiver)
+	}
+	res := Error{}
+	relID, err := rec.relationshipID(ctx, cl, relationshipName)
+	if err != nil {
+		return err
+	}
+
+	val := make(map[string]string)
+	val["busobid"] = rec.BusObID
+	val["busobrecid"] = rec.BusObRecID
+	val["relationshipid"] = relID
+	val["childbusobid"] = childRec.BusObID
+	val["childbusobrecid"] = childRec.BusObRecID
+
+	return cl.request(ctx, "GET", linkBusObRecURI, val, nil, &res)
+}
+
+// UnlinkBusinessObjectRecord unlinks the Cherwell BusinessObjectRecord from a given Child BusinessObjectRecord
+func (rec *BusinessObjectRecord) UnlinkBusinessObjectRecord(ctx context.Context, cl *Client, childRec *BusinessObjectRecord, relationshipName string) error {
+	if rec == nil || childRec == nil {
+		return fmt.Errorf("%w: BusinessObjectRecord", ErrNilReceiver)
+	}
+	res := Error{}
+	relID, err := rec.relationshipID(ctx, cl, relationshipName)
+	if err != nil {
+		return err
+	}
+
+	val := make(map[string]string)
+	val["busobid"] = rec.BusObID
+	val["busobrecid"] = rec.BusObRecID
+	val["relationshipid"] = relID
+	val["childbusobid"] = childRec.BusObID
+	val["childbusobrecid"] = childRec.BusObRecID
+
+	return cl.request(ctx, "DELETE", unlinkBusObRecURI, val, nil, &res)
+}
+
+// GetRelationshipID returns the ID of the Relationship with the given RelationshipID or DisplayName
+func (sch *BusinessObjectSchema) GetRelationshipID(relationshipName string) (string, error) {
+	r, err := sch.Relationship(relationshipName)
+	if err != nil {
+		return "", err
+	}
+	return r.RelationshipID, nil
+}
+
+// GetTeamMembers retreives all Members linked to the Team with the given Name, as configured by the
+// TeamSettings of the Client. The BusinessObjects and Fields are resolved by their internal Names and the
+// Relationship by its RelationshipID or the BusObID of its Target, so it works regardless of the Language
+// of the Cherwell Server.
+func (cl *Client) GetTeamMembers(ctx context.Context, teamName string) (*[]BusinessObjectRecord, error) {
+	var teamRecID string
+	settings := cl.teamSettings()
+	bo, err := cl.ResolveBusinessObject(ctx, settings.BusinessObject)
+	if err != nil {
+		return nil, err
+	}
+	relID, err := cl.teamMembersRelationship(ctx, bo, settings)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := bo.SearchMultipleBusinessObjectRecords(ctx, cl,
+		[]string{settings.TypeField, "eq", settings.TypeValue},
+		[]string{settings.NameField, "eq", teamName},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range *teams {
+		if t.BusObPublicID == teamName {
+			teamRecID = t.BusObRecID
+		}
+		cl.log().Debug("team found", "publicId", t.BusObPublicID, "busObRecId", t.BusObRecID)
+	}
+	if teamRecID == "" {
+		return nil, fmt.Errorf("%w: Team: %v", ErrNotFound, teamName)
+	}
+
+	team, err := bo.GetBusinessObjectRecordByRecID(ctx, cl, teamRecID)
+	if err != nil {
+		return nil, err
+	}
+	return team.GetRelatedBusinessObjects(ctx, cl, relID)
+}
+
+// teamMembersRelationship returns the RelationshipID of the Relationship of the Team-BusinessObject to its Members
+func (cl *Client) teamMembersRelationship(ctx context.Context, bo *BusinessObject, settings TeamSettings) (string, error) {
+	sch, err := bo.GetBusinessObjectSchema(ctx, cl)
+	if err != nil {
+		return "", err
+	}
+	if settings.MembersRelationship != "" {
+		return sch.GetRelationshipID(settings.MembersRelationship)
+	}
+	member, err := cl.ResolveBusinessObject(ctx, settings.MemberBusinessObject)
+	if err != nil {
+		return "", err
+	}
+	r, err := sch.RelationshipTo(member.BusObID)
+	if err != nil {
+		return "", err
+	}
+	return r.RelationshipID, nil
+}
+
+// unJson unmarshals a given io.ReadCloser to a given interface
+func unJson(input io.ReadCloser, data interface{}) error {
+	ioBody, err := ioutil.ReadAll(input)
+	if err != nil {
+		return err
+	}
+	ioBody = bytes.TrimPrefix(ioBody, []byte("\xef\xbb\xbf"))
+	if len(bytes.TrimSpace(ioBody)) == 0 {
+		return nil
+	}
+	return json.Unmarshal(ioBody, &data)
+}
+
+// busObIDValues returns the Values for formatURI of Requests regarding the given BusObID
+func busObIDValues(busObID string) map[string]string {
+	val := make(map[string]string)
+	val["busobid"] = busObID
+	return val
+}
+
+// formatURI replaces the Placeholders in a given URI with the given Values
+func formatURI(uri string, values map[string]string) string {
+	if val, ok := values["busobid"]; ok {
+		uri = strings.Replace(uri, busobid, val, 1)
+	}
+	if val, ok := values["busobrecid"]; ok {
+		uri = strings.Replace(uri, busobrecid, val, 1)
+	}
+	if val, ok := values["busobpublicid"]; ok {
+		uri = strings.Replace(uri, busobpublicid, val, 1)
+	}
+	if val, ok := values["childbusobid"]; ok {
+		uri = strings.Replace(uri, childbusobid, val, 1)
+	}
+	if val, ok := values["childbusobrecid"]; ok {
+		uri = strings.Replace(uri, childbusobrecid, val, 1)
+	}
+	if val, ok := values["relationshipid"]; ok {
+		uri = strings.Replace(uri, relationshipid, val, 1)
+	}
+	if val, ok := values["busobname"]; ok {
+		uri = strings.Replace(uri, busobname, url.PathEscape(val), 1)
+	}
+	if val, ok := values["busobtype"]; ok {
+		uri = strings.Replace(uri, busobtype, val, 1)
+	}
+	return uri
+}
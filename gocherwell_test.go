@@ -0,0 +1,129 @@
+package gocherwell_test
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/itsscb/gocherwell"
+	"github.com/itsscb/gocherwell/cherwelltest"
+)
+
+// newTestServer returns a Server with the BusinessObject of testComputer.
+func newTestServer() *cherwelltest.Server {
+	return cherwelltest.NewServer(testComputer())
+}
+
+// testComputer returns the BusinessObject ConfigComputer (DisplayName Computer) with 30 Records
+// NB000 to NB029. Every third Record is Retired, the others Active, the AssetType cycles
+// through Notebook, Desktop and Phone and the Cost is ten times the Number.
+func testComputer() cherwelltest.BusinessObject {
+	records := []map[string]string{}
+	for i := 0; i < 30; i++ {
+		status := "Active"
+		if i%3 == 0 {
+			status = "Retired"
+		}
+		records = append(records, map[string]string{
+			"AssetName":    fmt.Sprintf("NB%03d", i),
+			"Status":       status,
+			"AssetType":    []string{"Notebook", "Desktop", "Phone"}[i%3],
+			"Cost":         fmt.Sprint(i * 10),
+			"LastModified": fmt.Sprintf("2026-01-%02dT10:00:00", i%28+1),
+		})
+	}
+	return cherwelltest.BusinessObject{
+		BusObID:     "BO1",
+		Name:        "ConfigComputer",
+		DisplayName: "Computer",
+		Fields: []cherwelltest.Field{
+			{Name: "AssetName"},
+			{Name: "Status"},
+			{Name: "AssetType"},
+			{Name: "Cost", Type: "Number"},
+			{Name: "LastModified", Type: "DateTime", HasDate: true, HasTime: true},
+		},
+		Records: records,
+	}
+}
+
+// assetNames returns the AssetName of each BusinessObjectRecord
+func assetNames(recs *[]gocherwell.BusinessObjectRecord) []string {
+	names := []string{}
+	for _, r := range *recs {
+		names = append(names, fmt.Sprint(r.FieldValues["AssetName"]))
+	}
+	return names
+}
+
+// recordingHooks records the RequestInfo and ResponseInfo of every call by its Endpoint.
+type recordingHooks struct {
+	mu       sync.Mutex
+	calls    map[string][]gocherwell.ResponseInfo
+	requests map[string][]gocherwell.RequestInfo
+}
+
+func (h *recordingHooks) OnRequestStart(ctx context.Context, info gocherwell.RequestInfo) context.Context {
+	return ctx
+}
+
+func (h *recordingHooks) OnRequestEnd(ctx context.Context, info gocherwell.RequestInfo, res gocherwell.ResponseInfo) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.calls == nil {
+		h.calls = make(map[string][]gocherwell.ResponseInfo)
+		h.requests = make(map[string][]gocherwell.RequestInfo)
+	}
+	h.calls[info.Endpoint] = append(h.calls[info.Endpoint], res)
+	h.requests[info.Endpoint] = append(h.requests[info.Endpoint], info)
+}
+
+// infos returns the recorded RequestInfos of all calls whose Endpoint contains the given String
+func (h *recordingHooks) infos(endpoint string) []gocherwell.RequestInfo {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	res := []gocherwell.RequestInfo{}
+	for e, infos := range h.requests {
+		if strings.Contains(strings.ToLower(e), endpoint) {
+			res = append(res, infos...)
+		}
+	}
+	return res
+}
+
+// results returns the recorded ResponseInfos of all calls whose Endpoint contains the given String
+func (h *recordingHooks) results(endpoint string) []gocherwell.ResponseInfo {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	res := []gocherwell.ResponseInfo{}
+	for e, calls := range h.calls {
+		if strings.Contains(strings.ToLower(e), endpoint) {
+			res = append(res, calls...)
+		}
+	}
+	return res
+}
+
+// grantTypes returns the grant_type of every Request to the token endpoint of the Server
+func grantTypes(srv *cherwelltest.Server) []string {
+	grants := []string{}
+	for _, r := range srv.Requests() {
+		if u, err := url.Parse(r.Path); err == nil && strings.HasSuffix(strings.ToLower(u.Path), "/token") {
+			form, _ := url.ParseQuery(r.Body)
+			grants = append(grants, form.Get("grant_type"))
+		}
+	}
+	return grants
+}
+
+// containsAny reports whether s contains any of the given Strings, ignoring case
+func containsAny(s string, substrs ...string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(strings.ToLower(s), strings.ToLower(sub)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package gocherwell
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// RequestInfo describes a call of the Cherwell API.
+type RequestInfo struct {
+	Method string
+	// Endpoint is the URI-Template of the call, e.g. "api/V1/getbusinessobject/busobid/$/busobrecid/#".
+	Endpoint string
+	URI      string
+	// BusObID is the ID of the BusinessObject the call regards, if any.
+	BusObID string
+}
+
+// ResponseInfo describes the Result of a call of the Cherwell API.
+type ResponseInfo struct {
+	// StatusCode is the HTTP-Status of the last attempt or 0 if no Response was received.
+	StatusCode    int
+	RequestBytes  int64
+	ResponseBytes int64
+	// Duration includes all retries and the time spent waiting for them.
+	Duration time.Duration
+	Retries  int
+	Err      error
+}
+
+// Hooks are called around every call of the Cherwell API, e.g. to collect Metrics or create Traces.
+// The Context returned by OnRequestStart is used for the call and passed to OnRequestEnd.
+type Hooks interface {
+	OnRequestStart(ctx context.Context, info RequestInfo) context.Context
+	OnRequestEnd(ctx context.Context, info RequestInfo, res ResponseInfo)
+}
+
+// WithHooks adds Hooks to the Client. They are called in the given order.
+func WithHooks(h ...Hooks) Option {
+	return func(o *options) {
+		o.hookList = append(o.hookList, h...)
+	}
+}
+
+// multiHooks calls several Hooks in order.
+type multiHooks []Hooks
+
+func (m multiHooks) OnRequestStart(ctx context.Context, info RequestInfo) context.Context {
+	for _, h := range m {
+		ctx = h.OnRequestStart(ctx, info)
+	}
+	return ctx
+}
+
+func (m multiHooks) OnRequestEnd(ctx context.Context, info RequestInfo, res ResponseInfo) {
+	for _, h := range m {
+		h.OnRequestEnd(ctx, info, res)
+	}
+}
+
+// transfer counts the Bytes sent and received by a single attempt.
+type transfer struct {
+	RequestBytes  int64
+	ResponseBytes int64
+}
+
+// countingReader counts the Bytes read from the wrapped io.ReadCloser.
+type countingReader struct {
+	io.ReadCloser
+	n *int64
+}
+
+func (r countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	*r.n += int64(n)
+	return n, err
+}
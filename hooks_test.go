@@ -0,0 +1,126 @@
+package gocherwell_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/itsscb/gocherwell"
+)
+
+// orderKey is the Context-Key of the Hooks started for a call.
+type orderKey struct{}
+
+// orderHooks logs its calls with its Name to a shared log, so the Order of several Hooks can be checked.
+type orderHooks struct {
+	name string
+	mu   *sync.Mutex
+	log  *[]string
+}
+
+func (h orderHooks) OnRequestStart(ctx context.Context, info gocherwell.RequestInfo) context.Context {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	*h.log = append(*h.log, h.name+" start "+info.Endpoint)
+	started, _ := ctx.Value(orderKey{}).(string)
+	return context.WithValue(ctx, orderKey{}, started+h.name)
+}
+
+func (h orderHooks) OnRequestEnd(ctx context.Context, info gocherwell.RequestInfo, res gocherwell.ResponseInfo) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	*h.log = append(*h.log, fmt.Sprintf("%v end %v %v (started %v)", h.name, info.Endpoint, res.StatusCode, ctx.Value(orderKey{})))
+}
+
+func TestHooksOrder(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	mu := &sync.Mutex{}
+	log := []string{}
+	cl := srv.NewClient(gocherwell.WithHooks(orderHooks{"a", mu, &log}, orderHooks{"b", mu, &log}))
+	if _, err := cl.Login(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"a start token",
+		"b start token",
+		"a end token 200 (started ab)",
+		"b end token 200 (started ab)",
+	}
+	if strings.Join(log, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Hooks called\n%v\nwant\n%v", strings.Join(log, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestHooks(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       func(ctx context.Context, cl *gocherwell.Client, bo *gocherwell.BusinessObject) error
+		fail     int
+		endpoint string
+		method   string
+		busObID  string
+		status   int
+		retries  int
+	}{
+		{"login", func(ctx context.Context, cl *gocherwell.Client, bo *gocherwell.BusinessObject) error {
+			_, err := cl.Login(ctx)
+			return err
+		}, 0, "token", "POST", "", 200, 0},
+		{"schema", func(ctx context.Context, cl *gocherwell.Client, bo *gocherwell.BusinessObject) error {
+			_, err := bo.GetBusinessObjectSchema(ctx, cl)
+			return err
+		}, 0, "getbusinessobjectschema", "GET", "BO1", 200, 0},
+		{"retried search", func(ctx context.Context, cl *gocherwell.Client, bo *gocherwell.BusinessObject) error {
+			_, err := bo.NewQuery().Count(ctx, cl)
+			return err
+		}, http.StatusServiceUnavailable, "getsearchresults", "POST", "BO1", 200, 1},
+		{"failed record", func(ctx context.Context, cl *gocherwell.Client, bo *gocherwell.BusinessObject) error {
+			_, err := bo.GetBusinessObjectRecordByRecID(ctx, cl, "missing")
+			return err
+		}, 0, "busobrecid", "GET", "BO1", 404, 0},
+		{"logout", func(ctx context.Context, cl *gocherwell.Client, bo *gocherwell.BusinessObject) error {
+			return cl.Logout(ctx)
+		}, 0, "logout", "DELETE", "", 200, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer()
+			defer srv.Close()
+			ctx := context.Background()
+			hooks := &recordingHooks{}
+			cl := srv.NewClient(gocherwell.WithHooks(hooks), gocherwell.WithRetryPolicy(gocherwell.RetryPolicy{MaxAttempts: 2}))
+			bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+			if err != nil {
+				t.Fatal(err)
+			}
+			hooks.calls, hooks.requests = nil, nil
+			if tt.fail != 0 {
+				srv.FailNext(tt.endpoint, tt.fail, http.Header{"Retry-After": []string{"0"}})
+			}
+			err = tt.op(ctx, cl, bo)
+			if (err != nil) != (tt.status >= 400) {
+				t.Fatalf("error = %v, want Status %v", err, tt.status)
+			}
+
+			infos := hooks.infos(tt.endpoint)
+			res := hooks.results(tt.endpoint)
+			if len(infos) != 1 || len(res) != 1 {
+				t.Fatalf("Hooks = %+v, want 1 call of %v", hooks.calls, tt.endpoint)
+			}
+			if info := infos[0]; info.Method != tt.method || info.BusObID != tt.busObID || !strings.HasPrefix(info.URI, srv.URL) {
+				t.Errorf("RequestInfo = %+v, want %v %v of %q", info, tt.method, tt.endpoint, tt.busObID)
+			}
+			r := res[0]
+			if r.StatusCode != tt.status || r.Retries != tt.retries || (r.Err != nil) != (err != nil) {
+				t.Errorf("ResponseInfo = %+v, want Status %v with %v retries", r, tt.status, tt.retries)
+			}
+			if r.RequestBytes <= 0 || r.ResponseBytes <= 0 || r.Duration <= 0 {
+				t.Errorf("ResponseInfo = %+v, want Bytes and Duration", r)
+			}
+		})
+	}
+}
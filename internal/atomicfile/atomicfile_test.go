@@ -0,0 +1,50 @@
+package atomicfile_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/itsscb/gocherwell/internal/atomicfile"
+)
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cache.json")
+	for _, data := range []string{"first", "second"} {
+		if err := atomicfile.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != data {
+			t.Errorf("content = %q, want %q", got, data)
+		}
+	}
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if perm := info.Mode().Perm(); perm != 0o600 {
+			t.Errorf("permissions = %v, want %v", perm, os.FileMode(0o600))
+		}
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("files = %v, want only %v", len(entries), path)
+	}
+}
+
+func TestWriteFileMissingDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "cache.json")
+	if err := atomicfile.WriteFile(path, []byte("data"), 0o600); err == nil {
+		t.Errorf("WriteFile() error = nil, want an error for a missing directory")
+	}
+}
@@ -0,0 +1,261 @@
+package gocherwell
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DefaultPageSize is the Number of BusinessObjectRecords requested per Page if the Query sets no PageSize.
+const DefaultPageSize = 200
+
+// Iterator pages lazily through the BusinessObjectRecords matching a Query.
+//
+//	it := q.Iterator(ctx, cl)
+//	defer it.Close()
+//	for it.Next() {
+//		rec := it.Record()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	cl       *Client
+	compile  func(ctx context.Context) (*compiledQuery, error)
+	prefetch bool
+
+	c        *compiledQuery
+	page     int64
+	total    int64
+	buf      []BusinessObjectRecord
+	cur      *BusinessObjectRecord
+	pending  chan page
+	done     bool
+	err      error
+	pageSize int64
+	streams  []*branchStream
+	seen     map[string]bool
+	seenKey  string
+}
+
+// page is a fetched Page of Results.
+type page struct {
+	records []BusinessObjectRecord
+	rows    int
+	total   int64
+	last    bool
+	err     error
+}
+
+// branchStream pages lazily through the Results of the Search of one Branch of a Query.
+type branchStream struct {
+	search Search
+	page   int64
+	total  int64
+	buf    []BusinessObjectRecord
+	done   bool
+}
+
+// PageSize sets the Number of BusinessObjectRecords requested per Page. Default is DefaultPageSize.
+func (q *Query) PageSize(n int) *Query {
+	q.pageSize = int64(n)
+	return q
+}
+
+// Prefetch enables fetching the next Page while the current one is processed.
+func (q *Query) Prefetch(enabled bool) *Query {
+	q.prefetch = enabled
+	return q
+}
+
+// Iterator returns an Iterator over all BusinessObjectRecords matching the Query.
+// The Query is compiled and the first Page is fetched by the first call of Next.
+func (q *Query) Iterator(ctx context.Context, cl *Client) *Iterator {
+	return newIterator(ctx, cl, q.pageSize, q.prefetch, func(ctx context.Context) (*compiledQuery, error) {
+		return q.compile(ctx, cl)
+	})
+}
+
+// newIterator returns an Iterator requesting Pages of the given Size, DefaultPageSize if it is not
+// positive. The compiledQuery is returned by compile, which is called by the first call of Next
+func newIterator(ctx context.Context, cl *Client, pageSize int64, prefetch bool, compile func(ctx context.Context) (*compiledQuery, error)) *Iterator {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &Iterator{ctx: ctx, cancel: cancel, cl: cl, compile: compile, prefetch: prefetch, pageSize: pageSize}
+}
+
+// Next advances to the next BusinessObjectRecord and reports whether there is one.
+// It returns false at the end of the Results or on an Error, which is returned by Err.
+func (it *Iterator) Next() bool {
+	for {
+		if len(it.buf) > 0 {
+			it.cur = &it.buf[0]
+			it.buf = it.buf[1:]
+			return true
+		}
+		it.cur = nil
+		if it.done || it.err != nil {
+			it.cancel()
+			return false
+		}
+		if it.c == nil {
+			c, err := it.compile(it.ctx)
+			if err != nil {
+				it.err = err
+				it.cancel()
+				return false
+			}
+			it.c = c
+		}
+
+		var p page
+		if len(it.c.branches) > 0 {
+			p = it.fetchBranches()
+		} else if it.pending != nil {
+			p = <-it.pending
+			it.pending = nil
+		} else {
+			p = it.fetch(it.page + 1)
+		}
+		if p.err != nil {
+			it.err = p.err
+			it.cancel()
+			return false
+		}
+		it.page++
+		it.total = p.total
+		it.buf = p.records
+		if len(it.c.branches) > 0 {
+			it.done = p.last
+		} else if int64(p.rows) < it.pageSize || (p.total > 0 && it.page*it.pageSize >= p.total) {
+			it.done = true
+		} else if it.prefetch {
+			it.pending = make(chan page, 1)
+			go func(ch chan page, n int64) {
+				ch <- it.fetch(n)
+			}(it.pending, it.page+1)
+		}
+	}
+}
+
+// Close stops the Iteration and cancels a Page fetched in advance by Prefetch. It has to be called
+// if the Iteration is stopped before Next returns false and always returns nil.
+func (it *Iterator) Close() error {
+	it.cancel()
+	it.done = true
+	it.buf = nil
+	it.cur = nil
+	it.pending = nil
+	return nil
+}
+
+// Record returns the current BusinessObjectRecord.
+func (it *Iterator) Record() *BusinessObjectRecord {
+	return it.cur
+}
+
+// Err returns the Error that ended the Iteration, if any.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// TotalRows returns the Number of BusinessObjectRecords matching the Filters sent to Cherwell
+// as reported with the last Page. Conditions applied by the Client are not considered.
+// For Queries split into several Searches it is the Sum over all Searches, which counts
+// BusinessObjectRecords found by several of them more than once.
+func (it *Iterator) TotalRows() int64 {
+	return it.total
+}
+
+// fetch retreives a single Page and applies the residual Conditions
+func (it *Iterator) fetch(n int64) page {
+	return it.fetchSearch(it.c.search, n)
+}
+
+// fetchBranches merges the next Page of Results of the Searches of all Branches in the Order of
+// compiledQuery.less, skipping BusinessObjectRecords already returned. The Searches are paged
+// lazily, so at most one Page per Branch is held in memory. Duplicates are merged among the
+// Records with the same compiledQuery.sortKey, so only the RecIDs of those are remembered
+func (it *Iterator) fetchBranches() page {
+	if it.streams == nil {
+		for _, search := range it.c.branches {
+			it.streams = append(it.streams, &branchStream{search: search})
+		}
+	}
+	records := []BusinessObjectRecord{}
+	for int64(len(records)) < it.pageSize {
+		var next *branchStream
+		for _, s := range it.streams {
+			if err := it.fill(s); err != nil {
+				return page{err: err}
+			}
+			if len(s.buf) > 0 && (next == nil || it.c.less(&s.buf[0], &next.buf[0])) {
+				next = s
+			}
+		}
+		if next == nil {
+			return page{records: records, rows: len(records), total: it.branchTotal(), last: true}
+		}
+		rec := next.buf[0]
+		next.buf = next.buf[1:]
+		if key := it.c.sortKey(&rec); it.seen == nil || key != it.seenKey {
+			it.seen, it.seenKey = make(map[string]bool), key
+		}
+		if !it.seen[rec.BusObRecID] {
+			it.seen[rec.BusObRecID] = true
+			records = append(records, rec)
+		}
+	}
+	return page{records: records, rows: len(records), total: it.branchTotal()}
+}
+
+// fill fetches the next Page of the Branch if all its BusinessObjectRecords were merged
+func (it *Iterator) fill(s *branchStream) error {
+	for len(s.buf) == 0 && !s.done {
+		s.page++
+		p := it.fetchSearch(s.search, s.page)
+		if p.err != nil {
+			return p.err
+		}
+		s.buf, s.total = p.records, p.total
+		s.done = int64(p.rows) < it.pageSize || (p.total > 0 && s.page*it.pageSize >= p.total)
+	}
+	return nil
+}
+
+// branchTotal returns the Sum of the TotalRows of all Branches
+func (it *Iterator) branchTotal() int64 {
+	var total int64
+	for _, s := range it.streams {
+		total += s.total
+	}
+	return total
+}
+
+// fetchSearch retreives a single Page of the given Search and applies the residual Conditions
+func (it *Iterator) fetchSearch(search Search, n int64) page {
+	search.PageNumber = n
+	search.PageSize = it.pageSize
+	res := SearchResult{}
+	if err := it.cl.request(it.ctx, "POST", getSearchResultsURI, busObIDValues(search.BusObID), &search, &res); err != nil {
+		return page{err: fmt.Errorf("failed to fetch Page %v: %w", n, err)}
+	}
+	if res.HasPrompts && len(res.BusinessObjects) == 0 && len(res.Prompts) > 0 {
+		texts := []string{}
+		for _, p := range res.Prompts {
+			texts = append(texts, p.Text)
+		}
+		return page{err: fmt.Errorf("%w: Prompts without Value: %v", ErrValidation, strings.Join(texts, ", "))}
+	}
+	records := []BusinessObjectRecord{}
+	for _, r := range res.BusinessObjects {
+		if it.c.match(&r) {
+			records = append(records, *r.processFields())
+		}
+	}
+	return page{records: records, rows: len(res.BusinessObjects), total: res.TotalRows}
+}
@@ -0,0 +1,125 @@
+package gocherwell_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/itsscb/gocherwell"
+)
+
+func TestIterator(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    func(q *gocherwell.Query) *gocherwell.Query
+		pageSize int
+		prefetch bool
+		records  int
+		requests int
+		total    int64
+	}{
+		{"single page", func(q *gocherwell.Query) *gocherwell.Query { return q }, 0, false, 30, 1, 30},
+		{"exact pages", func(q *gocherwell.Query) *gocherwell.Query { return q }, 10, false, 30, 3, 30},
+		{"partial last page", func(q *gocherwell.Query) *gocherwell.Query { return q }, 7, false, 30, 5, 30},
+		{"prefetch", func(q *gocherwell.Query) *gocherwell.Query { return q }, 7, true, 30, 5, 30},
+		{"filtered by cherwell", func(q *gocherwell.Query) *gocherwell.Query { return q.Where("Status").Eq("Active") }, 7, false, 20, 3, 20},
+		{"filtered by client", func(q *gocherwell.Query) *gocherwell.Query { return q.Where("Cost").Between(50, 150) }, 7, true, 11, 4, 25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer()
+			defer srv.Close()
+			ctx := context.Background()
+			cl := srv.NewClient()
+			bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			it := tt.query(bo.NewQuery()).PageSize(tt.pageSize).Prefetch(tt.prefetch).Iterator(ctx, cl)
+			defer it.Close()
+			names := []string{}
+			for it.Next() {
+				names = append(names, fmt.Sprint(it.Record().FieldValues["AssetName"]))
+			}
+			if err := it.Err(); err != nil {
+				t.Fatalf("Err() = %v", err)
+			}
+			if len(names) != tt.records {
+				t.Errorf("Records = %v, want %v", names, tt.records)
+			}
+			seen := map[string]bool{}
+			for _, name := range names {
+				if seen[name] {
+					t.Errorf("Record %v returned twice", name)
+				}
+				seen[name] = true
+			}
+			if got := countRequests(srv, "getsearchresults"); got != tt.requests {
+				t.Errorf("Requests = %v, want %v", got, tt.requests)
+			}
+			if got := it.TotalRows(); got != tt.total {
+				t.Errorf("TotalRows() = %v, want %v", got, tt.total)
+			}
+			if it.Next() || it.Record() != nil {
+				t.Errorf("Next() after the end = true, want false")
+			}
+		})
+	}
+}
+
+func TestIteratorClose(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	ctx := context.Background()
+	cl := srv.NewClient()
+	bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	it := bo.NewQuery().PageSize(5).Prefetch(true).Iterator(ctx, cl)
+	if !it.Next() {
+		t.Fatalf("Next() = false, Err() = %v", it.Err())
+	}
+	if err := it.Close(); err != nil {
+		t.Errorf("Close() = %v, want nil", err)
+	}
+	if it.Next() || it.Record() != nil {
+		t.Errorf("Next() after Close = true, want false")
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("Err() after Close = %v, want nil", err)
+	}
+	if got := countRequests(srv, "getsearchresults"); got > 2 {
+		t.Errorf("Requests = %v, want at most the first and the prefetched Page", got)
+	}
+}
+
+func TestIteratorCancelled(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	cl := srv.NewClient()
+	bo, err := cl.ResolveBusinessObject(context.Background(), "Computer")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	it := bo.NewQuery().PageSize(5).Iterator(ctx, cl)
+	defer it.Close()
+	n := 0
+	for it.Next() {
+		if n++; n == 3 {
+			cancel()
+		}
+	}
+	if !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("Err() = %v, want context.Canceled", it.Err())
+	}
+	if n != 5 {
+		t.Errorf("Records = %v, want the 5 Records of the first Page", n)
+	}
+}
@@ -0,0 +1,152 @@
+package gocherwell
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// WithRateLimit limits the Client to the given Number of Requests per Second with the given Burst.
+// A rate of 0 disables the limit.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(o *options) {
+		o.rateLimit = requestsPerSecond
+		o.burst = burst
+	}
+}
+
+// WithMaxInFlight limits the Number of concurrent Requests of the Client. 0 disables the limit.
+func WithMaxInFlight(n int) Option {
+	return func(o *options) {
+		o.maxInFlight = n
+	}
+}
+
+// LimiterStats contains the Statistics of the client-side Limiter of a Client.
+type LimiterStats struct {
+	// Requests is the Number of Requests which passed the Limiter.
+	Requests uint64
+	// Throttled is the Number of Requests which had to wait for the Limiter.
+	Throttled uint64
+	// Waiting is the Number of Requests currently waiting for the Limiter.
+	Waiting int
+	// InFlight is the Number of Requests currently sent to the Cherwell Server.
+	InFlight int
+	// TotalWait is the accumulated Time Requests waited for the Limiter.
+	TotalWait time.Duration
+}
+
+// limiter is a token bucket combined with a semaphore for the Requests in flight.
+type limiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	sem    chan struct{}
+	stats  LimiterStats
+}
+
+// newLimiter returns a Pointer to a limiter or nil if no limit is configured
+func newLimiter(rate float64, burst, maxInFlight int) *limiter {
+	if rate <= 0 && maxInFlight <= 0 {
+		return nil
+	}
+	l := &limiter{rate: rate, burst: float64(burst)}
+	if l.burst < 1 {
+		l.burst = 1
+	}
+	l.tokens = l.burst
+	if maxInFlight > 0 {
+		l.sem = make(chan struct{}, maxInFlight)
+	}
+	return l
+}
+
+// acquire waits until a Request may be sent and returns a function which must be called when it is done
+func (l *limiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	start := time.Now()
+	l.mu.Lock()
+	l.stats.Waiting++
+	l.mu.Unlock()
+	defer func() {
+		l.mu.Lock()
+		l.stats.Waiting--
+		l.mu.Unlock()
+	}()
+
+	if err := l.wait(ctx); err != nil {
+		return nil, err
+	}
+	if l.sem != nil {
+		select {
+		case l.sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	waited := time.Since(start)
+	l.mu.Lock()
+	l.stats.Requests++
+	l.stats.InFlight++
+	l.stats.TotalWait += waited
+	if waited > time.Millisecond {
+		l.stats.Throttled++
+	}
+	l.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			l.stats.InFlight--
+			l.mu.Unlock()
+			if l.sem != nil {
+				<-l.sem
+			}
+		})
+	}, nil
+}
+
+// wait reserves a token of the bucket and sleeps until it is available
+func (l *limiter) wait(ctx context.Context) error {
+	if l.rate <= 0 {
+		return nil
+	}
+	l.mu.Lock()
+	now := time.Now()
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+	l.tokens--
+	var d time.Duration
+	if l.tokens < 0 {
+		d = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if err := sleep(ctx, d); err != nil {
+		// Give back the reserved token.
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Stats returns the Statistics of the client-side Limiter. They are zero if no limit is configured.
+func (cl *Client) Stats() LimiterStats {
+	if cl.limiter == nil {
+		return LimiterStats{}
+	}
+	cl.limiter.mu.Lock()
+	defer cl.limiter.mu.Unlock()
+	return cl.limiter.stats
+}
@@ -0,0 +1,141 @@
+package gocherwell_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/itsscb/gocherwell"
+)
+
+func TestLimiter(t *testing.T) {
+	tests := []struct {
+		name        string
+		rate        float64
+		burst       int
+		maxInFlight int
+		parallel    int
+		minDuration time.Duration
+		maxDuration time.Duration
+		throttled   bool
+	}{
+		{"rate limits sequential requests", 20, 1, 0, 1, 200 * time.Millisecond, 5 * time.Second, true},
+		{"burst passes immediately", 1, 10, 0, 1, 0, 500 * time.Millisecond, false},
+		{"rate limits parallel requests", 20, 2, 0, 6, 150 * time.Millisecond, 5 * time.Second, true},
+		{"max in flight limits concurrency", 0, 0, 2, 6, 30 * time.Millisecond, 5 * time.Second, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer()
+			defer srv.Close()
+			ctx := context.Background()
+			inFlight := &concurrency{delay: 10 * time.Millisecond}
+			cl := srv.NewClient(
+				gocherwell.WithRateLimit(tt.rate, tt.burst),
+				gocherwell.WithMaxInFlight(tt.maxInFlight),
+				gocherwell.WithMiddleware(inFlight.middleware),
+			)
+			bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Wait until the bucket is full again, a large burst still has enough tokens left.
+			if tt.rate > 0 && tt.burst < 5 {
+				time.Sleep(time.Duration(float64(tt.burst) / tt.rate * float64(time.Second)))
+			}
+			before := cl.Stats()
+			inFlight.reset()
+
+			const n = 6
+			start := time.Now()
+			errs := make(chan error, n)
+			sem := make(chan struct{}, tt.parallel)
+			for i := 0; i < n; i++ {
+				sem <- struct{}{}
+				go func() {
+					defer func() { <-sem }()
+					_, err := bo.GetBusinessObjectSchema(ctx, cl)
+					errs <- err
+				}()
+			}
+			for i := 0; i < n; i++ {
+				if err := <-errs; err != nil {
+					t.Errorf("GetBusinessObjectSchema() error = %v", err)
+				}
+			}
+			d := time.Since(start)
+
+			stats := cl.Stats()
+			if d < tt.minDuration || d > tt.maxDuration {
+				t.Errorf("duration = %v, want between %v and %v", d, tt.minDuration, tt.maxDuration)
+			}
+			if got := stats.Requests - before.Requests; got != n {
+				t.Errorf("Stats().Requests = %v more, want %v", got, n)
+			}
+			if got := stats.Throttled > before.Throttled; got != tt.throttled {
+				t.Errorf("throttled = %v, want %v (Stats: %+v)", got, tt.throttled, stats)
+			}
+			if stats.InFlight != 0 || stats.Waiting != 0 {
+				t.Errorf("Stats() = %+v, want nothing in flight or waiting", stats)
+			}
+			if tt.maxInFlight > 0 && inFlight.max > tt.maxInFlight {
+				t.Errorf("max in flight = %v, want at most %v", inFlight.max, tt.maxInFlight)
+			}
+		})
+	}
+}
+
+func TestLimiterCancelled(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	// The Burst covers the Login and the Summary, the Schema has to wait 10s.
+	cl := srv.NewClient(gocherwell.WithRateLimit(0.1, 2))
+	bo, err := cl.ResolveBusinessObject(context.Background(), "ConfigComputer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := bo.GetBusinessObjectSchema(ctx, cl); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetBusinessObjectSchema() error = %v, want context.DeadlineExceeded", err)
+	}
+	if stats := cl.Stats(); stats.Waiting != 0 || stats.InFlight != 0 {
+		t.Errorf("Stats() = %+v, want nothing in flight or waiting", stats)
+	}
+}
+
+// concurrency is a Middleware measuring the maximum Number of concurrent Requests.
+type concurrency struct {
+	delay time.Duration
+
+	mu      sync.Mutex
+	current int
+	max     int
+}
+
+func (c *concurrency) middleware(next http.RoundTripper) http.RoundTripper {
+	return gocherwell.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		c.mu.Lock()
+		c.current++
+		if c.current > c.max {
+			c.max = c.current
+		}
+		c.mu.Unlock()
+		defer func() {
+			c.mu.Lock()
+			c.current--
+			c.mu.Unlock()
+		}()
+		time.Sleep(c.delay)
+		return next.RoundTrip(req)
+	})
+}
+
+func (c *concurrency) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.max = 0
+}
@@ -0,0 +1,187 @@
+package gocherwell
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Logger receives the diagnostic Messages of a Client as Message and alternating Keys and Values.
+// It is implemented by *slog.Logger of log/slog.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// Level is the Severity of a Message of WriterLogger.
+type Level int
+
+// Levels of WriterLogger, they match the Levels of log/slog.
+const (
+	LevelDebug Level = -4
+	LevelInfo  Level = 0
+	LevelWarn  Level = 4
+	LevelError Level = 8
+)
+
+// String returns the Name of the Level.
+func (l Level) String() string {
+	switch {
+	case l >= LevelError:
+		return "ERROR"
+	case l >= LevelWarn:
+		return "WARN"
+	case l >= LevelInfo:
+		return "INFO"
+	}
+	return "DEBUG"
+}
+
+// WithLogger sets the Logger of the Client. By default nothing is logged.
+// Values of Keys like password, access_token or refresh_token are redacted before they reach the Logger.
+func WithLogger(l Logger) Option {
+	return func(o *options) {
+		o.logger = l
+	}
+}
+
+// WriterLogger is a minimal Logger writing one line of Key=Value pairs per Message to an io.Writer.
+type WriterLogger struct {
+	mu    sync.Mutex
+	w     io.Writer
+	level Level
+}
+
+// NewWriterLogger returns a Pointer to a WriterLogger writing all Messages of at least the given Level to w.
+func NewWriterLogger(w io.Writer, level Level) *WriterLogger {
+	return &WriterLogger{w: w, level: level}
+}
+
+// Debug implements Logger.
+func (l *WriterLogger) Debug(msg string, args ...interface{}) { l.log(LevelDebug, msg, args) }
+
+// Info implements Logger.
+func (l *WriterLogger) Info(msg string, args ...interface{}) { l.log(LevelInfo, msg, args) }
+
+// Warn implements Logger.
+func (l *WriterLogger) Warn(msg string, args ...interface{}) { l.log(LevelWarn, msg, args) }
+
+// Error implements Logger.
+func (l *WriterLogger) Error(msg string, args ...interface{}) { l.log(LevelError, msg, args) }
+
+// log writes a single Message if its Level is enabled
+func (l *WriterLogger) log(level Level, msg string, args []interface{}) {
+	if level < l.level {
+		return
+	}
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "time=%v level=%v msg=%q", time.Now().Format(time.RFC3339), level, msg)
+	for i := 0; i < len(args); i += 2 {
+		if i+1 < len(args) {
+			fmt.Fprintf(&b, " %v=%q", args[i], fmt.Sprint(args[i+1]))
+		} else {
+			fmt.Fprintf(&b, " !BADKEY=%q", fmt.Sprint(args[i]))
+		}
+	}
+	b.WriteString("\n")
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = io.WriteString(l.w, b.String())
+}
+
+// nopLogger discards all Messages.
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+
+// redacted replaces secret Values in Log-Messages.
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are Keys whose Values are never logged.
+var sensitiveKeys = []string{"password", "access_token", "refresh_token", "accesstoken", "refreshtoken", "authorization", "token"}
+
+// sensitivePattern finds secrets in URIs, Form-Bodies, JSON and Headers.
+var sensitivePattern = regexp.MustCompile(`(?i)("?(?:password|access_token|refresh_token)"?\s*[=:]\s*"?)([^"&\s,}]+)|(bearer\s+)([^\s"]+)`)
+
+// Redact replaces the Values of Passwords, AccessTokens and RefreshTokens in the given String.
+func Redact(s string) string {
+	return sensitivePattern.ReplaceAllStringFunc(s, func(m string) string {
+		sub := sensitivePattern.FindStringSubmatch(m)
+		if sub[1] != "" {
+			return sub[1] + redacted
+		}
+		return sub[3] + redacted
+	})
+}
+
+// redactingLogger redacts all Messages before passing them to the wrapped Logger.
+type redactingLogger struct {
+	Logger
+}
+
+func (l redactingLogger) Debug(msg string, args ...interface{}) {
+	l.Logger.Debug(Redact(msg), redactArgs(args)...)
+}
+
+func (l redactingLogger) Info(msg string, args ...interface{}) {
+	l.Logger.Info(Redact(msg), redactArgs(args)...)
+}
+
+func (l redactingLogger) Warn(msg string, args ...interface{}) {
+	l.Logger.Warn(Redact(msg), redactArgs(args)...)
+}
+
+func (l redactingLogger) Error(msg string, args ...interface{}) {
+	l.Logger.Error(Redact(msg), redactArgs(args)...)
+}
+
+// redactArgs returns a copy of the alternating Keys and Values with secret Values redacted
+func redactArgs(args []interface{}) []interface{} {
+	res := make([]interface{}, len(args))
+	for i := 0; i < len(args); i++ {
+		if i%2 == 0 && i+1 < len(args) {
+			res[i] = args[i]
+			if key, ok := args[i].(string); ok && isSensitiveKey(key) {
+				i++
+				res[i] = redacted
+			}
+			continue
+		}
+		switch v := args[i].(type) {
+		case string:
+			res[i] = Redact(v)
+		case error:
+			res[i] = Redact(v.Error())
+		default:
+			res[i] = v
+		}
+	}
+	return res
+}
+
+// isSensitiveKey reports whether the Value of the given Key must not be logged
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range sensitiveKeys {
+		if key == k {
+			return true
+		}
+	}
+	return false
+}
+
+// log returns the Logger of the Client
+func (cl *Client) log() Logger {
+	if cl.logger == nil {
+		return nopLogger{}
+	}
+	return cl.logger
+}
@@ -0,0 +1,110 @@
+package gocherwell_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/itsscb/gocherwell"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"grant_type=password&username=u&password=secret&client_id=c", "grant_type=password&username=u&password=[REDACTED]&client_id=c"},
+		{"refresh_token=abc123&grant_type=refresh_token", "refresh_token=[REDACTED]&grant_type=refresh_token"},
+		{`{"access_token":"abc","token_type":"bearer","refresh_token":"def"}`, `{"access_token":"[REDACTED]","token_type":"bearer","refresh_token":"[REDACTED]"}`},
+		{`{"Password": "secret"}`, `{"Password": "[REDACTED]"}`},
+		{"Authorization: Bearer abc.def", "Authorization: Bearer [REDACTED]"},
+		{"https://host/CherwellAPI/token?auth_mode=Internal", "https://host/CherwellAPI/token?auth_mode=Internal"},
+		{"nothing secret", "nothing secret"},
+	}
+	for _, tt := range tests {
+		if got := gocherwell.Redact(tt.input); got != tt.want {
+			t.Errorf("Redact(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestLoggerRedactsClientMessages(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	srv.Password = "pw-5ecret"
+	ctx := context.Background()
+	buf := &bytes.Buffer{}
+	cl := srv.NewClient(gocherwell.WithLogger(gocherwell.NewWriterLogger(buf, gocherwell.LevelDebug)))
+	bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	secrets := []string{srv.Password, cl.Token().AccessToken, cl.Token().RefreshToken}
+
+	// Expire the Tokens and fail the refresh, so the Client logs the failure and logs in again.
+	srv.ExpireTokens()
+	srv.FailNext("token", http.StatusBadRequest, nil)
+	if _, err := bo.NewQuery().Count(ctx, cl); err != nil {
+		t.Fatal(err)
+	}
+	secrets = append(secrets, cl.Token().AccessToken, cl.Token().RefreshToken)
+
+	// Values of Fields are logged on save, so secrets in them are redacted as well.
+	rec, err := bo.NewQuery().Where("AssetName").Eq("NB001").One(ctx, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.FieldValues["Status"] = "password=hunter2"
+	if _, err := rec.SaveBusinessObjectRecord(ctx, cl); err != nil {
+		t.Fatal(err)
+	}
+	secrets = append(secrets, "hunter2")
+
+	log := buf.String()
+	for _, want := range []string{"cherwell request unauthorized", "token refresh failed", `/token?auth_mode=Internal" status="200"`, `value="password=[REDACTED]"`} {
+		if !strings.Contains(log, want) {
+			t.Errorf("log does not contain %q:\n%v", want, log)
+		}
+	}
+	for _, s := range secrets {
+		if strings.Contains(log, s) {
+			t.Errorf("log contains secret %q:\n%v", s, log)
+		}
+	}
+}
+
+func TestWriterLoggerLevel(t *testing.T) {
+	tests := []struct {
+		level gocherwell.Level
+		want  []string
+	}{
+		{gocherwell.LevelDebug, []string{"DEBUG", "INFO", "WARN", "ERROR"}},
+		{gocherwell.LevelInfo, []string{"INFO", "WARN", "ERROR"}},
+		{gocherwell.LevelWarn, []string{"WARN", "ERROR"}},
+		{gocherwell.LevelError, []string{"ERROR"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			l := gocherwell.NewWriterLogger(buf, tt.level)
+			l.Debug("message", "key", "value")
+			l.Info("message", "key", "value")
+			l.Warn("message", "key", "value")
+			l.Error("message", "key")
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if len(lines) != len(tt.want) {
+				t.Fatalf("lines = %q, want %v", lines, tt.want)
+			}
+			for i, line := range lines {
+				if !strings.Contains(line, "level="+tt.want[i]+` msg="message"`) {
+					t.Errorf("line %v = %q, want level %v", i, line, tt.want[i])
+				}
+			}
+			if last := lines[len(lines)-1]; !strings.HasSuffix(last, ` !BADKEY="key"`) {
+				t.Errorf("line %q, want !BADKEY for a Key without Value", last)
+			}
+		})
+	}
+}
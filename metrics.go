@@ -0,0 +1,174 @@
+package gocherwell
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultDurationBuckets are the upper Bounds in Seconds of the Histogram of PrometheusMetrics.
+var DefaultDurationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// PrometheusMetrics implements Hooks and collects Metrics of all calls of the Cherwell API.
+// As http.Handler it serves them in the Prometheus text exposition format, e.g. at /metrics.
+type PrometheusMetrics struct {
+	mu       sync.Mutex
+	buckets  []float64
+	inFlight int64
+	series   map[metricKey]*metricSeries
+}
+
+// metricKey identifies the Labels of a Series.
+type metricKey struct {
+	method   string
+	endpoint string
+	busObID  string
+	status   string
+}
+
+// metricSeries contains the Values of a Series.
+type metricSeries struct {
+	requests      uint64
+	errors        uint64
+	retries       uint64
+	requestBytes  int64
+	responseBytes int64
+	durationSum   float64
+	durationCount []uint64
+}
+
+// NewPrometheusMetrics returns a Pointer to a PrometheusMetrics using the given Histogram-Buckets
+// in Seconds or DefaultDurationBuckets if none are given.
+func NewPrometheusMetrics(buckets ...float64) *PrometheusMetrics {
+	if len(buckets) == 0 {
+		buckets = DefaultDurationBuckets
+	}
+	b := append([]float64{}, buckets...)
+	sort.Float64s(b)
+	return &PrometheusMetrics{
+		buckets: b,
+		series:  make(map[metricKey]*metricSeries),
+	}
+}
+
+// OnRequestStart implements Hooks.
+func (m *PrometheusMetrics) OnRequestStart(ctx context.Context, info RequestInfo) context.Context {
+	m.mu.Lock()
+	m.inFlight++
+	m.mu.Unlock()
+	return ctx
+}
+
+// OnRequestEnd implements Hooks.
+func (m *PrometheusMetrics) OnRequestEnd(ctx context.Context, info RequestInfo, res ResponseInfo) {
+	key := metricKey{
+		method:   info.Method,
+		endpoint: info.Endpoint,
+		busObID:  info.BusObID,
+		status:   strconv.Itoa(res.StatusCode),
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inFlight--
+	s, ok := m.series[key]
+	if !ok {
+		s = &metricSeries{durationCount: make([]uint64, len(m.buckets))}
+		m.series[key] = s
+	}
+	s.requests++
+	if res.Err != nil {
+		s.errors++
+	}
+	s.retries += uint64(res.Retries)
+	s.requestBytes += res.RequestBytes
+	s.responseBytes += res.ResponseBytes
+	d := res.Duration.Seconds()
+	s.durationSum += d
+	for i, b := range m.buckets {
+		if d <= b {
+			s.durationCount[i]++
+		}
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (m *PrometheusMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WriteText(w)
+}
+
+// WriteText writes all Metrics in the Prometheus text exposition format to w.
+func (m *PrometheusMetrics) WriteText(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys := make([]metricKey, 0, len(m.series))
+	for k := range m.series {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.endpoint != b.endpoint {
+			return a.endpoint < b.endpoint
+		}
+		if a.method != b.method {
+			return a.method < b.method
+		}
+		if a.busObID != b.busObID {
+			return a.busObID < b.busObID
+		}
+		return a.status < b.status
+	})
+
+	b := &strings.Builder{}
+	counter := func(name, help string, value func(s *metricSeries) string) {
+		fmt.Fprintf(b, "# HELP %v %v\n# TYPE %v counter\n", name, help, name)
+		for _, k := range keys {
+			fmt.Fprintf(b, "%v{%v} %v\n", name, k.labels(), value(m.series[k]))
+		}
+	}
+	counter("cherwell_requests_total", "Number of calls of the Cherwell API.",
+		func(s *metricSeries) string { return strconv.FormatUint(s.requests, 10) })
+	counter("cherwell_request_errors_total", "Number of failed calls of the Cherwell API.",
+		func(s *metricSeries) string { return strconv.FormatUint(s.errors, 10) })
+	counter("cherwell_request_retries_total", "Number of retries of calls of the Cherwell API.",
+		func(s *metricSeries) string { return strconv.FormatUint(s.retries, 10) })
+	counter("cherwell_request_bytes_total", "Bytes sent to the Cherwell API.",
+		func(s *metricSeries) string { return strconv.FormatInt(s.requestBytes, 10) })
+	counter("cherwell_response_bytes_total", "Bytes received from the Cherwell API.",
+		func(s *metricSeries) string { return strconv.FormatInt(s.responseBytes, 10) })
+
+	name := "cherwell_request_duration_seconds"
+	fmt.Fprintf(b, "# HELP %v Duration of calls of the Cherwell API including retries.\n# TYPE %v histogram\n", name, name)
+	for _, k := range keys {
+		s := m.series[k]
+		for i, bound := range m.buckets {
+			fmt.Fprintf(b, "%v_bucket{%v,le=%q} %v\n", name, k.labels(), strconv.FormatFloat(bound, 'g', -1, 64), s.durationCount[i])
+		}
+		fmt.Fprintf(b, "%v_bucket{%v,le=\"+Inf\"} %v\n", name, k.labels(), s.requests)
+		fmt.Fprintf(b, "%v_sum{%v} %v\n", name, k.labels(), strconv.FormatFloat(s.durationSum, 'g', -1, 64))
+		fmt.Fprintf(b, "%v_count{%v} %v\n", name, k.labels(), s.requests)
+	}
+
+	name = "cherwell_requests_in_flight"
+	fmt.Fprintf(b, "# HELP %v Number of calls of the Cherwell API in progress.\n# TYPE %v gauge\n%v %v\n", name, name, name, m.inFlight)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// labels formats the Labels of the Series
+func (k metricKey) labels() string {
+	return fmt.Sprintf(`method="%v",endpoint="%v",busobid="%v",status="%v"`,
+		escapeLabel(k.method), escapeLabel(k.endpoint), escapeLabel(k.busObID), escapeLabel(k.status))
+}
+
+// escapeLabel escapes a Label-Value for the Prometheus text exposition format
+func escapeLabel(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
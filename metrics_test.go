@@ -0,0 +1,125 @@
+package gocherwell_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itsscb/gocherwell"
+)
+
+func TestPrometheusMetrics(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	ctx := context.Background()
+	metrics := gocherwell.NewPrometheusMetrics(30, 0.000001)
+	cl := srv.NewClient(gocherwell.WithHooks(metrics), gocherwell.WithRetryPolicy(gocherwell.RetryPolicy{MaxAttempts: 2}))
+	bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.FailNext("getsearchresults", http.StatusServiceUnavailable, http.Header{"Retry-After": []string{"0"}})
+	if _, err := bo.NewQuery().Count(ctx, cl); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bo.GetBusinessObjectRecordByRecID(ctx, cl, "missing"); err == nil {
+		t.Fatal("GetBusinessObjectRecordByRecID() error = nil, want not found")
+	}
+
+	token := `method="POST",endpoint="token",busobid="",status="200"`
+	search := `method="POST",endpoint="api/V1/getsearchresults",busobid="BO1",status="200"`
+	missing := `method="GET",endpoint="api/v1/getbusinessobject/busobid/$/busobrecid/#",busobid="BO1",status="404"`
+	tests := []struct {
+		name  string
+		lines []string
+	}{
+		{"requests", []string{
+			"# TYPE cherwell_requests_total counter",
+			"cherwell_requests_total{" + token + "} 1",
+			"cherwell_requests_total{" + search + "} 1",
+			"cherwell_requests_total{" + missing + "} 1",
+		}},
+		{"errors", []string{
+			"cherwell_request_errors_total{" + search + "} 0",
+			"cherwell_request_errors_total{" + missing + "} 1",
+		}},
+		{"retries", []string{
+			"cherwell_request_retries_total{" + token + "} 0",
+			"cherwell_request_retries_total{" + search + "} 1",
+		}},
+		{"histogram", []string{
+			"# TYPE cherwell_request_duration_seconds histogram",
+			"cherwell_request_duration_seconds_bucket{" + search + `,le="1e-06"} 0`,
+			"cherwell_request_duration_seconds_bucket{" + search + `,le="30"} 1`,
+			"cherwell_request_duration_seconds_bucket{" + search + `,le="+Inf"} 1`,
+			"cherwell_request_duration_seconds_count{" + search + "} 1",
+		}},
+		{"in flight", []string{
+			"# TYPE cherwell_requests_in_flight gauge",
+			"cherwell_requests_in_flight 0",
+		}},
+	}
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %v, want the Prometheus text format", ct)
+	}
+	text := rec.Body.String()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, line := range tt.lines {
+				if !strings.Contains(text, line+"\n") {
+					t.Errorf("Metrics do not contain %v:\n%v", line, text)
+				}
+			}
+		})
+	}
+	if strings.Index(text, "{"+search) > strings.Index(text, "{"+token) {
+		t.Errorf("Series are not sorted by Endpoint:\n%v", text)
+	}
+}
+
+func TestPrometheusMetricsLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		busObID string
+		want    string
+	}{
+		{"plain", "BO1", `busobid="BO1"`},
+		{"quote", `BO"1`, `busobid="BO\"1"`},
+		{"backslash", `BO\1`, `busobid="BO\\1"`},
+		{"newline", "BO\n1", `busobid="BO\n1"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := gocherwell.NewPrometheusMetrics(0.5, 0.1)
+			info := gocherwell.RequestInfo{Method: "GET", Endpoint: "api/V1/getbusinessobjectschema/busobid/$", BusObID: tt.busObID}
+			ctx := m.OnRequestStart(context.Background(), info)
+			m.OnRequestEnd(ctx, info, gocherwell.ResponseInfo{StatusCode: 200, Duration: 300 * time.Millisecond, RequestBytes: 10, ResponseBytes: 20})
+
+			b := &strings.Builder{}
+			if err := m.WriteText(b); err != nil {
+				t.Fatal(err)
+			}
+			labels := `method="GET",endpoint="api/V1/getbusinessobjectschema/busobid/$",` + tt.want + `,status="200"`
+			for _, line := range []string{
+				"cherwell_requests_total{" + labels + "} 1",
+				"cherwell_request_bytes_total{" + labels + "} 10",
+				"cherwell_response_bytes_total{" + labels + "} 20",
+				"cherwell_request_duration_seconds_bucket{" + labels + `,le="0.1"} 0`,
+				"cherwell_request_duration_seconds_bucket{" + labels + `,le="0.5"} 1`,
+				"cherwell_request_duration_seconds_sum{" + labels + "} 0.3",
+			} {
+				if !strings.Contains(b.String(), line+"\n") {
+					t.Errorf("Metrics do not contain %v:\n%v", line, b.String())
+				}
+			}
+			if strings.Index(b.String(), `le="0.1"`) > strings.Index(b.String(), `le="0.5"`) {
+				t.Errorf("Buckets are not sorted:\n%v", b.String())
+			}
+		})
+	}
+}
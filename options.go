@@ -0,0 +1,137 @@
+package gocherwell
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+)
+
+// Option configures a Client created by NewClient.
+type Option func(*options)
+
+// options collects the Values of all Options given to NewClient.
+type options struct {
+	httpClient    *http.Client
+	transport     http.RoundTripper
+	tlsConfig     *tls.Config
+	timeout       time.Duration
+	middleware    []Middleware
+	tokenSource   TokenSource
+	retryPolicy   *RetryPolicy
+	rateLimit     float64
+	burst         int
+	maxInFlight   int
+	logger        Logger
+	hookList      []Hooks
+	metadataCache *MetadataCache
+	teamSettings  TeamSettings
+}
+
+// Middleware wraps a http.RoundTripper to intercept every HTTP-Request to the Cherwell Server,
+// e.g. to add Headers, log or measure them.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc is an adapter to use an ordinary function as http.RoundTripper.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WithHTTPClient sets the http.Client used for all HTTP-Requests.
+// The given http.Client is copied and not modified.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = hc
+	}
+}
+
+// WithTransport sets the http.RoundTripper used for all HTTP-Requests.
+// It takes precedence over the Transport of a http.Client given with WithHTTPClient.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = rt
+	}
+}
+
+// WithTLSConfig sets the tls.Config of the Transport, e.g. to trust a custom CA
+// via RootCAs or to authenticate with a client certificate via Certificates.
+// It is applied to a copy of the Transport if it is a *http.Transport. Any other http.RoundTripper
+// given with WithTransport or WithHTTPClient is used unchanged and has to be configured for TLS itself,
+// so the tls.Config is ignored.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(o *options) {
+		o.tlsConfig = cfg
+	}
+}
+
+// WithTimeout sets the Timeout of the http.Client for a single HTTP-Request.
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.timeout = d
+	}
+}
+
+// WithMiddleware adds Middleware in front of the Transport.
+// The first Middleware given is the outermost and sees every HTTP-Request first.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(o *options) {
+		o.middleware = append(o.middleware, mw...)
+	}
+}
+
+// HeaderMiddleware returns a Middleware which sets the given Headers on every HTTP-Request.
+func HeaderMiddleware(header http.Header) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			for k, v := range header {
+				req.Header[k] = v
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// buildHTTPClient creates the http.Client of the Client from the given options
+func (o *options) buildHTTPClient() *http.Client {
+	hc := &http.Client{}
+	if o.httpClient != nil {
+		*hc = *o.httpClient
+	}
+	if o.timeout > 0 {
+		hc.Timeout = o.timeout
+	}
+
+	rt := hc.Transport
+	if o.transport != nil {
+		rt = o.transport
+	}
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	if o.tlsConfig != nil {
+		if t, ok := rt.(*http.Transport); ok {
+			t = t.Clone()
+			t.TLSClientConfig = o.tlsConfig.Clone()
+			rt = t
+		}
+	}
+	for i := len(o.middleware) - 1; i >= 0; i-- {
+		rt = o.middleware[i](rt)
+	}
+	hc.Transport = rt
+	return hc
+}
+
+// hooks combines all Hooks given by WithHooks or returns nil if there are none
+func (o *options) hooks() Hooks {
+	switch len(o.hookList) {
+	case 0:
+		return nil
+	case 1:
+		return o.hookList[0]
+	}
+	return multiHooks(o.hookList)
+}
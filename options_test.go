@@ -0,0 +1,145 @@
+package gocherwell_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/itsscb/gocherwell"
+)
+
+// countingTransport returns a http.RoundTripper counting its HTTP-Requests before passing them to next
+func countingTransport(n *int32, next http.RoundTripper) http.RoundTripper {
+	return gocherwell.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(n, 1)
+		return next.RoundTrip(req)
+	})
+}
+
+func TestWithTLSConfig(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	tlsSrv := httptest.NewUnstartedServer(srv.Config.Handler)
+	// the rejected Certificates are expected
+	tlsSrv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	tlsSrv.StartTLS()
+	defer tlsSrv.Close()
+	roots := x509.NewCertPool()
+	roots.AddCert(tlsSrv.Certificate())
+
+	var custom int32
+	tests := []struct {
+		name    string
+		opts    []gocherwell.Option
+		wantErr bool
+	}{
+		{"untrusted certificate", nil, true},
+		{"trusted root", []gocherwell.Option{gocherwell.WithTLSConfig(&tls.Config{RootCAs: roots})}, false},
+		{"trusted root with http client", []gocherwell.Option{
+			gocherwell.WithHTTPClient(&http.Client{Transport: &http.Transport{}}),
+			gocherwell.WithTLSConfig(&tls.Config{RootCAs: roots}),
+		}, false},
+		{"ignored for custom transport", []gocherwell.Option{
+			gocherwell.WithTransport(countingTransport(&custom, &http.Transport{})),
+			gocherwell.WithTLSConfig(&tls.Config{RootCAs: roots}),
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]gocherwell.Option{gocherwell.WithRetryPolicy(gocherwell.RetryPolicy{MaxAttempts: 1})}, tt.opts...)
+			cl := gocherwell.NewClient(srv.User, srv.Password, srv.ClientID, tlsSrv.URL+"/", "Internal", "password", opts...)
+			_, err := cl.Login(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Login() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	if custom == 0 {
+		t.Errorf("custom Transport was not used")
+	}
+}
+
+func TestTransportPrecedence(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	var fromClient, fromOption int32
+	cl := srv.NewClient(
+		gocherwell.WithTransport(countingTransport(&fromOption, http.DefaultTransport)),
+		gocherwell.WithHTTPClient(&http.Client{Transport: countingTransport(&fromClient, http.DefaultTransport)}),
+	)
+	if _, err := cl.Login(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if fromOption == 0 || fromClient != 0 {
+		t.Errorf("Requests by WithTransport = %v, by WithHTTPClient = %v, want only WithTransport", fromOption, fromClient)
+	}
+
+	fromClient = 0
+	cl = srv.NewClient(gocherwell.WithHTTPClient(&http.Client{Transport: countingTransport(&fromClient, http.DefaultTransport)}))
+	if _, err := cl.Login(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if fromClient == 0 {
+		t.Errorf("Transport of WithHTTPClient was not used")
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		srv.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer slow.Close()
+	cl := gocherwell.NewClient(srv.User, srv.Password, srv.ClientID, slow.URL+"/", "Internal", "password",
+		gocherwell.WithTimeout(20*time.Millisecond),
+		gocherwell.WithRetryPolicy(gocherwell.RetryPolicy{MaxAttempts: 1}))
+	_, err := cl.Login(context.Background())
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Errorf("Login() error = %v, want a Timeout", err)
+	}
+}
+
+func TestHeaderMiddleware(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	var seen, outer int32
+	check := func(next http.RoundTripper) http.RoundTripper {
+		return gocherwell.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("X-Tenant") == "acme" {
+				atomic.AddInt32(&seen, 1)
+			}
+			return next.RoundTrip(req)
+		})
+	}
+	// before runs ahead of HeaderMiddleware and must not see the Header
+	before := func(next http.RoundTripper) http.RoundTripper {
+		return gocherwell.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("X-Tenant") != "" {
+				atomic.AddInt32(&outer, 1)
+			}
+			return next.RoundTrip(req)
+		})
+	}
+	cl := srv.NewClient(gocherwell.WithMiddleware(before, gocherwell.HeaderMiddleware(http.Header{"X-Tenant": {"acme"}}), check))
+	if _, err := cl.ResolveBusinessObject(context.Background(), "Computer"); err != nil {
+		t.Fatal(err)
+	}
+	if seen == 0 || int(seen) != len(srv.Requests()) {
+		t.Errorf("Requests with Header = %v, want all %v", seen, len(srv.Requests()))
+	}
+	if outer != 0 {
+		t.Errorf("Header was set before HeaderMiddleware on %v Requests", outer)
+	}
+}
@@ -0,0 +1,365 @@
+package gocherwell
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// tokenKind is the Kind of a token of an Expression.
+type tokenKind int
+
+// Kinds of tokens.
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenField
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+// token is a token of an Expression with its 1-based Position.
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// parser parses an Expression against a BusinessObjectSchema.
+type parser struct {
+	input  string
+	sch    *BusinessObjectSchema
+	tokens []token
+	next   int
+}
+
+// keywords of the Query Language, matched case-insensitively.
+var keywords = map[string]bool{
+	"AND": true, "OR": true, "NOT": true, "IN": true, "BETWEEN": true, "CONTAINS": true, "STARTSWITH": true,
+}
+
+// ParseExpr parses a textual Expression like
+//
+//	Status = "Active" AND AssetType IN ("Notebook", "Desktop") AND LastModified > 2026-01-01
+//
+// against the BusinessObjectSchema and returns it as Expr. Fields are given by FieldID, Name or
+// DisplayName, in square Brackets if they contain Spaces. The Operators are =, !=, <>, <, <=, >, >=,
+// CONTAINS, STARTSWITH, IN (...) and BETWEEN ... AND ..., combined with AND, OR, NOT and Parentheses.
+// Values are parsed by the Type of the Field. Invalid Expressions return a *ParseError.
+func ParseExpr(sch *BusinessObjectSchema, input string) (Expr, error) {
+	if sch == nil {
+		return Expr{}, &ParseError{Input: input, Pos: 1, Msg: "BusinessObjectSchema missing"}
+	}
+	tokens, err := tokenize(input)
+	if err != nil {
+		return Expr{}, err
+	}
+	p := &parser{input: input, sch: sch, tokens: tokens}
+	e, err := p.parseOr()
+	if err != nil {
+		return Expr{}, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return Expr{}, p.errorf(t, "expected AND, OR or end of Expression")
+	}
+	return e, nil
+}
+
+// ParseQuery parses a textual Expression like ParseExpr against the Schema of the BusinessObject
+// and returns a Query matching it. The Query reuses the Schema instead of retreiving it again.
+func (bo *BusinessObject) ParseQuery(ctx context.Context, cl *Client, input string) (*Query, error) {
+	sch, err := bo.GetBusinessObjectSchema(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+	e, err := ParseExpr(sch, input)
+	if err != nil {
+		return nil, err
+	}
+	q := bo.NewQuery().Match(e)
+	q.schema = sch
+	return q, nil
+}
+
+// tokenize splits an Expression into tokens
+func tokenize(input string) ([]token, error) {
+	tokens := []token{}
+	for i := 0; i < len(input); {
+		c := input[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: position(input, i)})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: position(input, i)})
+			i++
+		case c == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: position(input, i)})
+			i++
+		case c == '=' || c == '<' || c == '>' || c == '!':
+			op := string(c)
+			if i+1 < len(input) && (input[i+1] == '=' || (c == '<' && input[i+1] == '>')) {
+				op += string(input[i+1])
+			}
+			if op == "!" {
+				return nil, &ParseError{Input: input, Pos: position(input, i), Token: op, Msg: "unknown Operator, did you mean !="}
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op, pos: position(input, i)})
+			i += len(op)
+		case c == '"' || c == '\'':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(input) && input[j] != c; j++ {
+				if input[j] == '\\' && j+1 < len(input) {
+					j++
+				}
+				b.WriteByte(input[j])
+			}
+			if j >= len(input) {
+				return nil, &ParseError{Input: input, Pos: position(input, i), Token: input[i:], Msg: "unterminated String"}
+			}
+			tokens = append(tokens, token{kind: tokenString, text: b.String(), pos: position(input, i)})
+			i = j + 1
+		case c == '[':
+			j := strings.IndexByte(input[i:], ']')
+			if j < 0 {
+				return nil, &ParseError{Input: input, Pos: position(input, i), Token: input[i:], Msg: "unterminated Field, missing ]"}
+			}
+			tokens = append(tokens, token{kind: tokenField, text: strings.TrimSpace(input[i+1 : i+j]), pos: position(input, i)})
+			i += j + 1
+		default:
+			j := i
+			for j < len(input) && !strings.ContainsRune(" \t\n\r(),=<>!\"'[]", rune(input[j])) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: input[i:j], pos: position(input, i)})
+			i = j
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: position(input, len(input))}), nil
+}
+
+// position returns the 1-based Position in Runes of the Byte at Index i of the Input
+func position(input string, i int) int {
+	return utf8.RuneCountInString(input[:i]) + 1
+}
+
+// peek returns the next token without consuming it
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+// advance consumes and returns the next token
+func (p *parser) advance() token {
+	t := p.tokens[p.next]
+	if t.kind != tokenEOF {
+		p.next++
+	}
+	return t
+}
+
+// keyword reports whether the next token is the given Keyword and consumes it if so
+func (p *parser) keyword(kw string) bool {
+	if t := p.peek(); t.kind == tokenWord && strings.EqualFold(t.text, kw) {
+		p.next++
+		return true
+	}
+	return false
+}
+
+// errorf returns a ParseError pointing at the given token
+func (p *parser) errorf(t token, msg string) *ParseError {
+	return &ParseError{Input: p.input, Pos: t.pos, Token: t.text, Msg: msg}
+}
+
+// parseOr parses Expressions combined with OR
+func (p *parser) parseOr() (Expr, error) {
+	e, err := p.parseAnd()
+	if err != nil {
+		return e, err
+	}
+	exprs := []Expr{e}
+	for p.keyword("OR") {
+		e, err := p.parseAnd()
+		if err != nil {
+			return e, err
+		}
+		exprs = append(exprs, e)
+	}
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return Or(exprs...), nil
+}
+
+// parseAnd parses Expressions combined with AND
+func (p *parser) parseAnd() (Expr, error) {
+	e, err := p.parseUnary()
+	if err != nil {
+		return e, err
+	}
+	exprs := []Expr{e}
+	for p.keyword("AND") {
+		e, err := p.parseUnary()
+		if err != nil {
+			return e, err
+		}
+		exprs = append(exprs, e)
+	}
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return And(exprs...), nil
+}
+
+// parseUnary parses NOT, Parentheses and Conditions
+func (p *parser) parseUnary() (Expr, error) {
+	if p.keyword("NOT") {
+		e, err := p.parseUnary()
+		if err != nil {
+			return e, err
+		}
+		return Not(e), nil
+	}
+	if t := p.peek(); t.kind == tokenLParen {
+		p.advance()
+		e, err := p.parseOr()
+		if err != nil {
+			return e, err
+		}
+		if t := p.advance(); t.kind != tokenRParen {
+			return e, p.errorf(t, "expected )")
+		}
+		return e, nil
+	}
+	return p.parseCondition()
+}
+
+// parseCondition parses a Condition on a Field
+func (p *parser) parseCondition() (Expr, error) {
+	t := p.advance()
+	if t.kind != tokenField && (t.kind != tokenWord || keywords[strings.ToUpper(t.text)]) {
+		return Expr{}, p.errorf(t, "expected Field")
+	}
+	def, err := p.sch.FieldDefinition(t.text)
+	if err != nil {
+		return Expr{}, p.errorf(t, "unknown Field of "+p.sch.Name)
+	}
+	field := t.text
+
+	opTok := p.advance()
+	negate := false
+	if opTok.kind == tokenWord && strings.EqualFold(opTok.text, "NOT") {
+		negate = true
+		opTok = p.advance()
+	}
+	var e Expr
+	switch op := strings.ToUpper(opTok.text); {
+	case opTok.kind == tokenOperator && !negate:
+		v, err := p.parseValue(def)
+		if err != nil {
+			return e, err
+		}
+		switch op {
+		case "=":
+			e = Cond(field, OperatorEq, v)
+		case "!=", "<>":
+			e = Not(Cond(field, OperatorEq, v))
+		case "<":
+			e = Cond(field, OperatorLt, v)
+		case ">":
+			e = Cond(field, OperatorGt, v)
+		case "<=":
+			e = Or(Cond(field, OperatorLt, v), Cond(field, OperatorEq, v))
+		case ">=":
+			e = Or(Cond(field, OperatorGt, v), Cond(field, OperatorEq, v))
+		default:
+			return e, p.errorf(opTok, "unknown Operator")
+		}
+	case opTok.kind == tokenWord && (op == "CONTAINS" || op == "STARTSWITH"):
+		if def.Type != "" && def.Type != "Text" {
+			return e, p.errorf(opTok, op+" needs a Text Field, "+field+" is "+def.Type)
+		}
+		v, err := p.parseValue(def)
+		if err != nil {
+			return e, err
+		}
+		e = Cond(field, Operator(strings.ToLower(op)), v)
+	case opTok.kind == tokenWord && op == "IN":
+		if t := p.advance(); t.kind != tokenLParen {
+			return e, p.errorf(t, "expected ( after IN")
+		}
+		values := []interface{}{}
+		for {
+			v, err := p.parseValue(def)
+			if err != nil {
+				return e, err
+			}
+			values = append(values, v)
+			t := p.advance()
+			if t.kind == tokenRParen {
+				break
+			}
+			if t.kind != tokenComma {
+				return e, p.errorf(t, "expected , or )")
+			}
+		}
+		e = Cond(field, OperatorIn, values...)
+	case opTok.kind == tokenWord && op == "BETWEEN":
+		lower, err := p.parseValue(def)
+		if err != nil {
+			return e, err
+		}
+		if !p.keyword("AND") {
+			return e, p.errorf(p.peek(), "expected AND after lower Bound of BETWEEN")
+		}
+		upper, err := p.parseValue(def)
+		if err != nil {
+			return e, err
+		}
+		e = Cond(field, OperatorBetween, lower, upper)
+	default:
+		return e, p.errorf(opTok, "expected Operator after Field "+field)
+	}
+	if negate {
+		e = Not(e)
+	}
+	return e, nil
+}
+
+// parseValue parses the next token as Value of the Field
+func (p *parser) parseValue(def *FieldDefinition) (interface{}, error) {
+	t := p.advance()
+	if t.kind != tokenString && (t.kind != tokenWord || keywords[strings.ToUpper(t.text)]) {
+		return nil, p.errorf(t, "expected Value for Field "+def.Name)
+	}
+	switch {
+	case isDateTime(def):
+		v, err := parseTime(t.text)
+		if err != nil {
+			return nil, p.errorf(t, "invalid Date for Field "+def.Name)
+		}
+		return v, nil
+	case def.Type == "Number":
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, p.errorf(t, "invalid Number for Field "+def.Name)
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	case def.Type == "Logical":
+		switch strings.ToLower(t.text) {
+		case "true", "yes", "1":
+			return true, nil
+		case "false", "no", "0":
+			return false, nil
+		}
+		return nil, p.errorf(t, "invalid Logical for Field "+def.Name+", want true or false")
+	}
+	return t.text, nil
+}
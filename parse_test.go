@@ -0,0 +1,147 @@
+package gocherwell_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/itsscb/gocherwell"
+	"github.com/itsscb/gocherwell/cherwelltest"
+)
+
+// parseSchema is the BusinessObjectSchema the Query Language is tested against.
+var parseSchema = &gocherwell.BusinessObjectSchema{
+	BusObID: "BO1",
+	Name:    "ConfigComputer",
+	FieldDefinitions: []gocherwell.FieldDefinition{
+		{FieldID: "F1", Name: "Status", DisplayName: "Status", Type: "Text"},
+		{FieldID: "F2", Name: "Size", DisplayName: "Größe", Type: "Number"},
+		{FieldID: "F3", Name: "Active", DisplayName: "Aktiv", Type: "Logical"},
+		{FieldID: "F4", Name: "LastModified", DisplayName: "Letzte Änderung", Type: "DateTime", HasDate: true, HasTime: true},
+	},
+}
+
+func TestParseExpr(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{`Status = "Active"`, "Status eq Active"},
+		{`Status != 'Retired'`, "NOT Status eq Retired"},
+		{`Status <> Retired`, "NOT Status eq Retired"},
+		{`Größe >= 10`, "(Größe gt 10 OR Größe eq 10)"},
+		{`Size <= 1.50`, "(Size lt 1.5 OR Size eq 1.5)"},
+		{`Aktiv = yes AND Status CONTAINS "Act"`, "(Aktiv eq true AND Status contains Act)"},
+		{`Status STARTSWITH "A" OR NOT (Size < 3)`, "(Status startswith A OR NOT Size lt 3)"},
+		{`Status IN ("New", "Active")`, "Status in New,Active"},
+		{`Status NOT IN (New)`, "NOT Status in New"},
+		{`Size BETWEEN 1 AND 5`, "Size between 1,5"},
+		{`[Letzte Änderung] > 2026-01-01`, "Letzte Änderung gt 2026-01-01 00:00:00 +0000 UTC"},
+		{`status = "say \"hi\""`, `status eq say "hi"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			e, err := gocherwell.ParseExpr(parseSchema, tt.input)
+			if err != nil {
+				t.Fatalf("ParseExpr() error = %v", err)
+			}
+			if got := e.String(); got != tt.want {
+				t.Errorf("ParseExpr() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseExprErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		pos   int
+		token string
+		msg   string
+	}{
+		{"bang", `Status ! "x"`, 8, "!", "unknown Operator, did you mean !="},
+		{"unterminated string", `Status = "Act`, 10, `"Act`, "unterminated String"},
+		{"unterminated field", `[Größe = 1`, 1, "[Größe = 1", "unterminated Field, missing ]"},
+		{"trailing token", `Status = x y`, 12, "y", "expected AND, OR or end of Expression"},
+		{"missing paren", `(Status = x`, 12, "", "expected )"},
+		{"missing field", `= x`, 1, "=", "expected Field"},
+		{"keyword as field", `AND = x`, 1, "AND", "expected Field"},
+		{"unknown field", `Größe = 1 AND Färbung = rot`, 15, "Färbung", "unknown Field of ConfigComputer"},
+		{"unknown operator", `Größe == 1`, 7, "==", "unknown Operator"},
+		{"missing operator", `Größe 1`, 7, "1", "expected Operator after Field Größe"},
+		{"negated comparison", `Status NOT = x`, 12, "=", "expected Operator after Field Status"},
+		{"contains on number", `Größe CONTAINS 1`, 7, "CONTAINS", "CONTAINS needs a Text Field, Größe is Number"},
+		{"in without paren", `Status IN New`, 11, "New", "expected ( after IN"},
+		{"in without comma", `Status IN (New Active)`, 16, "Active", "expected , or )"},
+		{"between without and", `Größe BETWEEN 1 5`, 17, "5", "expected AND after lower Bound of BETWEEN"},
+		{"missing value", `Status =`, 9, "", "expected Value for Field Status"},
+		{"keyword as value", `Status = OR`, 10, "OR", "expected Value for Field Status"},
+		{"invalid number", `Größe > zehn`, 9, "zehn", "invalid Number for Field Size"},
+		{"invalid logical", `Aktiv = vielleicht`, 9, "vielleicht", "invalid Logical for Field Active, want true or false"},
+		{"invalid date", `[Letzte Änderung] > gestern`, 21, "gestern", "invalid Date for Field LastModified"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := gocherwell.ParseExpr(parseSchema, tt.input)
+			var perr *gocherwell.ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("ParseExpr() error = %v, want *ParseError", err)
+			}
+			if perr.Pos != tt.pos || perr.Token != tt.token || perr.Msg != tt.msg {
+				t.Errorf("ParseExpr() error at %d near %q: %q, want at %d near %q: %q", perr.Pos, perr.Token, perr.Msg, tt.pos, tt.token, tt.msg)
+			}
+			if !errors.Is(err, gocherwell.ErrValidation) {
+				t.Errorf("errors.Is(%v, ErrValidation) = false", err)
+			}
+		})
+	}
+}
+
+func TestParseExprWithoutSchema(t *testing.T) {
+	_, err := gocherwell.ParseExpr(nil, "Status = x")
+	var perr *gocherwell.ParseError
+	if !errors.As(err, &perr) || perr.Pos != 1 || perr.Msg != "BusinessObjectSchema missing" {
+		t.Errorf("ParseExpr(nil) error = %v, want BusinessObjectSchema missing at 1", err)
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	ctx := context.Background()
+	cl := srv.NewClient()
+	bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before := countRequests(srv, "getbusinessobjectschema")
+	q, err := bo.ParseQuery(ctx, cl, `(AssetType = Phone OR Cost > 250) AND Status != Retired`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recs, err := q.Records(ctx, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := countRequests(srv, "getbusinessobjectschema") - before; got != 1 {
+		t.Errorf("Schema requested %d times, want 1", got)
+	}
+	want := []string{"NB002", "NB005", "NB008", "NB011", "NB014", "NB017", "NB020", "NB023", "NB026", "NB028", "NB029"}
+	if got := assetNames(recs); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Records() = %v, want %v", got, want)
+	}
+}
+
+// countRequests returns the Number of Requests to the Server whose Path contains the given String
+func countRequests(srv *cherwelltest.Server, path string) int {
+	n := 0
+	for _, r := range srv.Requests() {
+		if strings.Contains(strings.ToLower(r.Path), path) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,577 @@
+package gocherwell
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Operator compares the Value of a Field in a Condition of a Query.
+type Operator string
+
+// Operators of a Query. Eq, Gt, Lt, Contains and StartsWith are evaluated by Cherwell,
+// In and Between are compiled to them.
+const (
+	OperatorEq         Operator = "eq"
+	OperatorGt         Operator = "gt"
+	OperatorLt         Operator = "lt"
+	OperatorContains   Operator = "contains"
+	OperatorStartsWith Operator = "startswith"
+	OperatorIn         Operator = "in"
+	OperatorBetween    Operator = "between"
+)
+
+// Inclusive Operators used for the Bounds of Between, which Cherwell does not know.
+const (
+	operatorGe Operator = "ge"
+	operatorLe Operator = "le"
+)
+
+// Condition is a single Condition of a Query on the Field with the given FieldID, FullFieldID, Name or DisplayName.
+type Condition struct {
+	Field    string
+	Operator Operator
+	Values   []interface{}
+}
+
+// String returns the Condition like "Status eq Active".
+func (c Condition) String() string {
+	values := make([]string, len(c.Values))
+	for i, v := range c.Values {
+		values[i] = fmt.Sprint(v)
+	}
+	return fmt.Sprintf("%v %v %v", c.Field, c.Operator, strings.Join(values, ","))
+}
+
+// Query searches the BusinessObjectRecords of a BusinessObject matching all its Conditions.
+// It is built fluently, e.g. bo.NewQuery().Where("Status").Eq("Active").Where("Type").In("Notebook", "Desktop").
+type Query struct {
+	busOb      *BusinessObject
+	conditions []Condition
+	// orSameField compiles all Conditions on the same Field to Filters, which Cherwell combines with OR
+	orSameField bool
+	pageSize    int64
+	prefetch    bool
+	sorting     []sortField
+	fields      []string
+	exprs       []Expr
+	allowScan   bool
+	// schema is the BusinessObjectSchema the Query was built with, e.g. by ParseQuery, so compile does not retreive it again
+	schema *BusinessObjectSchema
+}
+
+// SortDirection is the Direction to sort the Results of a Query by.
+type SortDirection int64
+
+// Directions of SortDirection as expected by Cherwell.
+const (
+	SortAscending  SortDirection = 1
+	SortDescending SortDirection = -1
+)
+
+// sortField is a Field the Results of a Query are sorted by.
+type sortField struct {
+	field     string
+	direction SortDirection
+}
+
+// FieldCondition adds a Condition on a Field to a Query.
+type FieldCondition struct {
+	q     *Query
+	field string
+}
+
+// compiledQuery is a Query compiled for a Search. Conditions Cherwell can not evaluate, like the
+// upper Bound of Between or a second Condition on the same Field, are kept as residual Conditions
+// and applied to the Results.
+// A Query with Expressions is compiled to one Search per Branch and the Expression is applied to the Results.
+type compiledQuery struct {
+	search   Search
+	schema   *BusinessObjectSchema
+	residual []residualCondition
+	expr     *node
+	branches []Search
+	// recIDField is the FieldID of the RecID Field the Branches are sorted by last
+	recIDField string
+}
+
+// residualCondition is a Condition evaluated by the Client.
+type residualCondition struct {
+	field    *FieldDefinition
+	operator Operator
+	value    string
+}
+
+// dateLayouts are the Layouts used to parse Date and Time Values of Cherwell.
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"1/2/2006 3:04:05 PM",
+	"1/2/2006 15:04:05",
+	"1/2/2006",
+	"15:04:05",
+	"3:04:05 PM",
+}
+
+// NewQuery returns a Pointer to an empty Query on the BusinessObject.
+func (bo *BusinessObject) NewQuery() *Query {
+	return &Query{busOb: bo}
+}
+
+// Where starts a Condition on the Field with the given FieldID, FullFieldID, Name or DisplayName.
+func (q *Query) Where(field string) *FieldCondition {
+	return &FieldCondition{q: q, field: field}
+}
+
+// Conditions returns the Conditions of the Query.
+func (q *Query) Conditions() []Condition {
+	return append([]Condition{}, q.conditions...)
+}
+
+// OrderBy sorts the Results by the given Field. Further calls sort by further Fields.
+func (q *Query) OrderBy(field string, direction SortDirection) *Query {
+	q.sorting = append(q.sorting, sortField{field: field, direction: direction})
+	return q
+}
+
+// Select limits the Fields of the Results to the given Fields instead of all Fields of the BusinessObject.
+// Fields needed to evaluate Conditions by the Client are added automatically.
+func (q *Query) Select(fields ...string) *Query {
+	q.fields = append(q.fields, fields...)
+	return q
+}
+
+// Count returns the Number of BusinessObjectRecords matching the Query. Without Conditions applied
+// by the Client it only requests a single Record and returns the TotalRows reported by Cherwell.
+func (q *Query) Count(ctx context.Context, cl *Client) (int64, error) {
+	c, err := q.compile(ctx, cl)
+	if err != nil {
+		return 0, err
+	}
+	if !c.clientSide() {
+		search := c.search
+		search.PageNumber = 1
+		search.PageSize = 1
+		search.IncludeAllFields = false
+		search.Fields = []string{}
+		search.Sorting = nil
+		res := SearchResult{}
+		if err := cl.request(ctx, "POST", getSearchResultsURI, busObIDValues(search.BusObID), &search, &res); err != nil {
+			return 0, err
+		}
+		return res.TotalRows, nil
+	}
+
+	counting := *q
+	counting.fields = []string{}
+	counting.sorting = nil
+	var n int64
+	it := counting.Iterator(ctx, cl)
+	for it.Next() {
+		n++
+	}
+	return n, it.Err()
+}
+
+// Eq matches Values equal to v, ignoring case.
+func (f *FieldCondition) Eq(v interface{}) *Query { return f.Is(OperatorEq, v) }
+
+// Gt matches Values greater than v.
+func (f *FieldCondition) Gt(v interface{}) *Query { return f.Is(OperatorGt, v) }
+
+// Lt matches Values lower than v.
+func (f *FieldCondition) Lt(v interface{}) *Query { return f.Is(OperatorLt, v) }
+
+// Contains matches Values containing v, ignoring case.
+func (f *FieldCondition) Contains(v interface{}) *Query { return f.Is(OperatorContains, v) }
+
+// StartsWith matches Values starting with v, ignoring case.
+func (f *FieldCondition) StartsWith(v interface{}) *Query { return f.Is(OperatorStartsWith, v) }
+
+// In matches Values equal to one of the given Values.
+func (f *FieldCondition) In(v ...interface{}) *Query { return f.Is(OperatorIn, v...) }
+
+// Between matches Values from lower to upper, both inclusive. Cherwell gets the lower Bound,
+// the upper Bound is applied to the Results by the Client.
+func (f *FieldCondition) Between(lower, upper interface{}) *Query {
+	return f.Is(OperatorBetween, lower, upper)
+}
+
+// Is adds a Condition with the given Operator and Values. Invalid Operators are reported
+// when the Query is compiled.
+func (f *FieldCondition) Is(op Operator, v ...interface{}) *Query {
+	f.q.conditions = append(f.q.conditions, Condition{
+		Field:    f.field,
+		Operator: Operator(strings.ToLower(string(op))),
+		Values:   v,
+	})
+	return f.q
+}
+
+// Compile validates the Query against the BusinessObjectSchema and returns the Search sent to Cherwell.
+// Conditions Cherwell can not evaluate are not part of the Search, they are applied to the Results by Records.
+func (q *Query) Compile(ctx context.Context, cl *Client) (*Search, error) {
+	c, err := q.compile(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+	return &c.search, nil
+}
+
+// Records retreives all BusinessObjectRecords matching the Query, Page by Page, and returns them
+func (q *Query) Records(ctx context.Context, cl *Client) (*[]BusinessObjectRecord, error) {
+	records := []BusinessObjectRecord{}
+	it := q.Iterator(ctx, cl)
+	for it.Next() {
+		records = append(records, *it.Record())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return &records, nil
+}
+
+// First retreives the first BusinessObjectRecord matching the Query and returns it.
+// It returns ErrNotFound if nothing matches.
+func (q *Query) First(ctx context.Context, cl *Client) (*BusinessObjectRecord, error) {
+	records, err := q.head(ctx, cl, 1)
+	if err != nil {
+		return nil, err
+	}
+	return &records[0], nil
+}
+
+// One retreives the only BusinessObjectRecord matching the Query and returns it.
+// It returns ErrNotFound if nothing matches and ErrMultipleResults if more than one Record matches.
+func (q *Query) One(ctx context.Context, cl *Client) (*BusinessObjectRecord, error) {
+	records, err := q.head(ctx, cl, 2)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) > 1 {
+		return nil, fmt.Errorf("%w: BusinessObjectRecord of %v matching %v", ErrMultipleResults, q.busOb.DisplayName, q.describe())
+	}
+	return &records[0], nil
+}
+
+// head retreives up to n BusinessObjectRecords matching the Query. Without residual Conditions
+// only n Records are requested. It returns ErrNotFound if nothing matches
+func (q *Query) head(ctx context.Context, cl *Client, n int) ([]BusinessObjectRecord, error) {
+	c, err := q.compile(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+	size := q.pageSize
+	if !c.clientSide() {
+		size = int64(n)
+	}
+	it := newIterator(ctx, cl, size, q.prefetch, func(context.Context) (*compiledQuery, error) {
+		return c, nil
+	})
+	defer it.Close()
+	records := []BusinessObjectRecord{}
+	for len(records) < n && it.Next() {
+		records = append(records, *it.Record())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: BusinessObjectRecord of %v matching %v", ErrNotFound, q.busOb.DisplayName, q.describe())
+	}
+	return records, nil
+}
+
+// compile validates the Conditions against the BusinessObjectSchema and splits them into
+// Filters evaluated by Cherwell and residual Conditions
+func (q *Query) compile(ctx context.Context, cl *Client) (*compiledQuery, error) {
+	if q == nil || q.busOb == nil {
+		return nil, fmt.Errorf("%w: BusinessObject", ErrNilReceiver)
+	}
+	sch := q.schema
+	if sch == nil || !strings.EqualFold(sch.BusObID, q.busOb.BusObID) {
+		var err error
+		if sch, err = q.busOb.GetBusinessObjectSchema(ctx, cl); err != nil {
+			return nil, err
+		}
+	}
+	c := &compiledQuery{
+		search: Search{
+			BusObID:          q.busOb.BusObID,
+			Filters:          []Filter{},
+			IncludeAllFields: true,
+		},
+		schema: sch,
+	}
+	filtered := make(map[string]bool)
+	conditions := q.conditions
+	if len(q.exprs) > 0 {
+		conditions = nil
+	}
+	for _, cond := range conditions {
+		def, err := sch.FieldDefinition(cond.Field)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]string, len(cond.Values))
+		for i, v := range cond.Values {
+			values[i] = formatValue(def, v)
+		}
+		if err := validateCondition(cond, len(values)); err != nil {
+			return nil, err
+		}
+
+		pushDown := q.orSameField || !filtered[def.FieldID]
+		filtered[def.FieldID] = true
+		filter := func(op Operator, value string) {
+			if pushDown {
+				c.search.Filters = append(c.search.Filters, Filter{FieldID: def.FieldID, FieldName: def.Name, Operator: string(op), Value: value})
+				return
+			}
+			c.residual = append(c.residual, residualCondition{field: def, operator: op, value: value})
+		}
+		switch cond.Operator {
+		case OperatorIn:
+			if !pushDown {
+				c.residual = append(c.residual, residualCondition{field: def, operator: OperatorIn, value: strings.Join(values, "\x00")})
+				continue
+			}
+			for _, v := range values {
+				filter(OperatorEq, v)
+			}
+		case OperatorBetween:
+			if pushDown {
+				// Cherwell combines Filters on the same Field with OR.
+				filter(OperatorGt, values[0])
+				filter(OperatorEq, values[0])
+			} else {
+				c.residual = append(c.residual, residualCondition{field: def, operator: operatorGe, value: values[0]})
+			}
+			c.residual = append(c.residual, residualCondition{field: def, operator: operatorLe, value: values[1]})
+		default:
+			filter(cond.Operator, values[0])
+		}
+	}
+
+	for _, sf := range q.sorting {
+		def, err := sch.FieldDefinition(sf.field)
+		if err != nil {
+			return nil, err
+		}
+		if sf.direction != SortAscending && sf.direction != SortDescending {
+			return nil, fmt.Errorf("%w: SortDirection invalid: %v (Field: %v)", ErrValidation, sf.direction, sf.field)
+		}
+		c.search.Sorting = append(c.search.Sorting, Sort{FieldID: def.FieldID, SortDirection: int64(sf.direction)})
+	}
+	if len(q.exprs) > 0 {
+		if err := q.compileExprs(c); err != nil {
+			return nil, err
+		}
+	}
+
+	if q.fields != nil {
+		fields := []string{}
+		selected := make(map[string]bool)
+		add := func(fieldID string) {
+			if !selected[fieldID] {
+				selected[fieldID] = true
+				fields = append(fields, fieldID)
+			}
+		}
+		for _, f := range q.fields {
+			def, err := sch.FieldDefinition(f)
+			if err != nil {
+				return nil, err
+			}
+			add(def.FieldID)
+		}
+		for _, r := range c.residual {
+			add(r.field.FieldID)
+		}
+		if c.expr != nil {
+			for _, f := range c.expr.fields() {
+				add(f)
+			}
+		}
+		if c.branches != nil {
+			for _, s := range c.search.Sorting {
+				add(s.FieldID)
+			}
+		}
+		c.search.IncludeAllFields = false
+		c.search.Fields = fields
+		for i := range c.branches {
+			c.branches[i].IncludeAllFields = false
+			c.branches[i].Fields = fields
+		}
+	}
+	return c, nil
+}
+
+// clientSide reports whether Conditions are applied to the Results by the Client
+func (c *compiledQuery) clientSide() bool {
+	return len(c.residual) > 0 || c.expr != nil
+}
+
+// validateCondition checks the Operator and the Number of Values of a Condition
+func validateCondition(cond Condition, n int) error {
+	switch cond.Operator {
+	case OperatorEq, OperatorGt, OperatorLt, OperatorContains, OperatorStartsWith:
+		if n != 1 {
+			return fmt.Errorf("%w: Condition invalid: %v %v needs 1 Value, got %v", ErrValidation, cond.Field, cond.Operator, n)
+		}
+	case OperatorIn:
+		if n == 0 {
+			return fmt.Errorf("%w: Condition invalid: %v %v needs at least 1 Value", ErrValidation, cond.Field, cond.Operator)
+		}
+	case OperatorBetween:
+		if n != 2 {
+			return fmt.Errorf("%w: Condition invalid: %v %v needs 2 Values, got %v", ErrValidation, cond.Field, cond.Operator, n)
+		}
+	default:
+		return fmt.Errorf("%w: Operator invalid: %q (Field: %v)", ErrValidation, cond.Operator, cond.Field)
+	}
+	return nil
+}
+
+// match reports whether the BusinessObjectRecord fulfills all residual Conditions and the Expression
+func (c *compiledQuery) match(rec *BusinessObjectRecord) bool {
+	if c.expr != nil && !c.expr.eval(rec) {
+		return false
+	}
+	for _, r := range c.residual {
+		value := ""
+		if f, err := rec.Field(r.field.FieldID); err == nil {
+			value = f.Value
+		}
+		if !matchValue(r.field, value, r.operator, r.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchValue evaluates an Operator like Cherwell: case-insensitive for Text and
+// numeric or chronological for Number and DateTime Fields
+func matchValue(def *FieldDefinition, value string, op Operator, operand string) bool {
+	v, o := strings.ToLower(value), strings.ToLower(operand)
+	switch op {
+	case OperatorEq:
+		return v == o || (value != "" && compareValues(def, value, operand) == 0)
+	case OperatorContains:
+		return strings.Contains(v, o)
+	case OperatorStartsWith:
+		return strings.HasPrefix(v, o)
+	case OperatorGt:
+		return value != "" && compareValues(def, value, operand) > 0
+	case OperatorLt:
+		return value != "" && compareValues(def, value, operand) < 0
+	case operatorGe:
+		return matchValue(def, value, OperatorGt, operand) || matchValue(def, value, OperatorEq, operand)
+	case operatorLe:
+		return matchValue(def, value, OperatorLt, operand) || matchValue(def, value, OperatorEq, operand)
+	case OperatorIn:
+		for _, o := range strings.Split(operand, "\x00") {
+			if matchValue(def, value, OperatorEq, o) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// compareValues compares two Values of a Field by the Type of the Field
+func compareValues(def *FieldDefinition, a, b string) int {
+	switch {
+	case def.Type == "Number":
+		x, errX := strconv.ParseFloat(a, 64)
+		y, errY := strconv.ParseFloat(b, 64)
+		if errX == nil && errY == nil {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	case isDateTime(def):
+		x, errX := parseTime(a)
+		y, errY := parseTime(b)
+		if errX == nil && errY == nil {
+			switch {
+			case x.Before(y):
+				return -1
+			case x.After(y):
+				return 1
+			}
+			return 0
+		}
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// isDateTime reports whether the Field holds a Date, a Time or both
+func isDateTime(def *FieldDefinition) bool {
+	return def.Type == "DateTime" || def.HasDate || def.HasTime
+}
+
+// parseTime parses a Date and Time Value of Cherwell
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: invalid Date: %q", ErrValidation, s)
+}
+
+// FormatValue formats a Value in the Wire format of Cherwell, as the Client does for Conditions, Prompts
+// and FieldValues on save. Times are formatted by HasDate and HasTime and Decimals by DecimalDigits of
+// the FieldDefinition, which may be nil.
+func FormatValue(def *FieldDefinition, v interface{}) string {
+	return formatValue(def, v)
+}
+
+// formatValue formats a Value for the Cherwell API. Without FieldDefinition Times are formatted
+// with Date and Time and Decimals with all significant Digits
+func formatValue(def *FieldDefinition, v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case time.Time:
+		switch {
+		case def != nil && def.HasDate && !def.HasTime:
+			return val.Format("2006-01-02")
+		case def != nil && def.HasTime && !def.HasDate:
+			return val.Format("15:04:05")
+		}
+		return val.Format("2006-01-02T15:04:05")
+	case bool:
+		if val {
+			return "True"
+		}
+		return "False"
+	case float32:
+		return formatDecimal(def, float64(val))
+	case float64:
+		return formatDecimal(def, val)
+	case fmt.Stringer:
+		return val.String()
+	}
+	return fmt.Sprint(v)
+}
+
+// formatDecimal formats a Decimal with the DecimalDigits of the Field or all significant Digits
+func formatDecimal(def *FieldDefinition, f float64) string {
+	if def != nil && def.DecimalDigits > 0 {
+		return strconv.FormatFloat(f, 'f', int(def.DecimalDigits), 64)
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,157 @@
+package gocherwell_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itsscb/gocherwell"
+)
+
+func TestQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query func(q *gocherwell.Query) *gocherwell.Query
+		count int
+		first []string
+	}{
+		{"all", func(q *gocherwell.Query) *gocherwell.Query { return q }, 30, []string{"NB000", "NB001"}},
+		{"eq", func(q *gocherwell.Query) *gocherwell.Query { return q.Where("Status").Eq("active") }, 20, []string{"NB001", "NB002", "NB004"}},
+		{"in", func(q *gocherwell.Query) *gocherwell.Query { return q.Where("AssetType").In("Notebook", "Phone") }, 20, []string{"NB000", "NB002", "NB003"}},
+		{"gt", func(q *gocherwell.Query) *gocherwell.Query { return q.Where("Cost").Gt(250) }, 4, []string{"NB026", "NB027", "NB028", "NB029"}},
+		{"lt", func(q *gocherwell.Query) *gocherwell.Query { return q.Where("Cost").Lt(30) }, 3, []string{"NB000", "NB001", "NB002"}},
+		{"between is inclusive", func(q *gocherwell.Query) *gocherwell.Query { return q.Where("Cost").Between(50, 150) }, 11, []string{"NB005", "NB006"}},
+		{"between dates", func(q *gocherwell.Query) *gocherwell.Query {
+			return q.Where("LastModified").Between(time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC), time.Date(2026, 1, 3, 10, 0, 0, 0, time.UTC))
+		}, 3, []string{"NB001", "NB002", "NB029"}},
+		{"contains", func(q *gocherwell.Query) *gocherwell.Query { return q.Where("AssetName").Contains("02") }, 11, []string{"NB002", "NB020"}},
+		{"starts with", func(q *gocherwell.Query) *gocherwell.Query { return q.Where("AssetName").StartsWith("nb01") }, 10, []string{"NB010"}},
+		{"conditions are combined with and", func(q *gocherwell.Query) *gocherwell.Query {
+			return q.Where("Status").Eq("Active").Where("AssetType").Eq("Desktop")
+		}, 10, []string{"NB001", "NB004"}},
+		{"order by", func(q *gocherwell.Query) *gocherwell.Query {
+			return q.Where("Status").Eq("Retired").OrderBy("Cost", gocherwell.SortDescending)
+		}, 10, []string{"NB027", "NB024", "NB021"}},
+		{"order by several fields", func(q *gocherwell.Query) *gocherwell.Query {
+			return q.Where("Cost").Lt(40).OrderBy("AssetType", gocherwell.SortAscending).OrderBy("Cost", gocherwell.SortDescending)
+		}, 4, []string{"NB001", "NB003", "NB000", "NB002"}},
+	}
+	srv := newTestServer()
+	defer srv.Close()
+	ctx := context.Background()
+	cl := srv.NewClient()
+	bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recs, err := tt.query(bo.NewQuery()).Records(ctx, cl)
+			if err != nil {
+				t.Fatalf("Records() error = %v", err)
+			}
+			names := assetNames(recs)
+			if len(names) != tt.count || strings.Join(names[:len(tt.first)], ",") != strings.Join(tt.first, ",") {
+				t.Errorf("Records() = %v, want %v starting with %v", names, tt.count, tt.first)
+			}
+			n, err := tt.query(bo.NewQuery()).Count(ctx, cl)
+			if err != nil || n != int64(tt.count) {
+				t.Errorf("Count() = %v, %v, want %v", n, err, tt.count)
+			}
+		})
+	}
+}
+
+func TestQueryInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		query func(q *gocherwell.Query) *gocherwell.Query
+		err   error
+	}{
+		{"unknown field", func(q *gocherwell.Query) *gocherwell.Query { return q.Where("Missing").Eq("x") }, gocherwell.ErrNotFound},
+		{"unknown operator", func(q *gocherwell.Query) *gocherwell.Query { return q.Where("Status").Is("like", "x") }, gocherwell.ErrValidation},
+		{"between without upper bound", func(q *gocherwell.Query) *gocherwell.Query { return q.Where("Cost").Is(gocherwell.OperatorBetween, 1) }, gocherwell.ErrValidation},
+		{"in without values", func(q *gocherwell.Query) *gocherwell.Query { return q.Where("Status").In() }, gocherwell.ErrValidation},
+		{"unknown sort field", func(q *gocherwell.Query) *gocherwell.Query { return q.OrderBy("Missing", gocherwell.SortAscending) }, gocherwell.ErrNotFound},
+		{"invalid sort direction", func(q *gocherwell.Query) *gocherwell.Query { return q.OrderBy("Cost", 3) }, gocherwell.ErrValidation},
+	}
+	srv := newTestServer()
+	defer srv.Close()
+	ctx := context.Background()
+	cl := srv.NewClient()
+	bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.query(bo.NewQuery()).Records(ctx, cl); !errors.Is(err, tt.err) {
+				t.Errorf("Records() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestQueryFirstOne(t *testing.T) {
+	tests := []struct {
+		name  string
+		query func(q *gocherwell.Query) *gocherwell.Query
+		first string
+		one   string
+		err   error
+	}{
+		{"single match", func(q *gocherwell.Query) *gocherwell.Query { return q.Where("AssetName").Eq("NB007") }, "NB007", "NB007", nil},
+		{"several matches", func(q *gocherwell.Query) *gocherwell.Query { return q.Where("Status").Eq("Retired") }, "NB000", "", gocherwell.ErrMultipleResults},
+		{"several matches by client", func(q *gocherwell.Query) *gocherwell.Query { return q.Where("Cost").Between(100, 110) }, "NB010", "", gocherwell.ErrMultipleResults},
+		{"no match", func(q *gocherwell.Query) *gocherwell.Query { return q.Where("AssetName").Eq("NB100") }, "", "", gocherwell.ErrNotFound},
+	}
+	srv := newTestServer()
+	defer srv.Close()
+	ctx := context.Background()
+	cl := srv.NewClient()
+	bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, err := tt.query(bo.NewQuery()).First(ctx, cl)
+			switch {
+			case tt.first == "" && !errors.Is(err, gocherwell.ErrNotFound):
+				t.Errorf("First() error = %v, want ErrNotFound", err)
+			case tt.first != "" && (err != nil || first.FieldValues["AssetName"] != tt.first):
+				t.Errorf("First() = %v, %v, want %v", first, err, tt.first)
+			}
+			one, err := tt.query(bo.NewQuery()).One(ctx, cl)
+			switch {
+			case tt.err != nil && !errors.Is(err, tt.err):
+				t.Errorf("One() error = %v, want %v", err, tt.err)
+			case tt.err == nil && (err != nil || one.FieldValues["AssetName"] != tt.one):
+				t.Errorf("One() = %v, %v, want %v", one, err, tt.one)
+			}
+		})
+	}
+}
+
+func TestQuerySelect(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	ctx := context.Background()
+	cl := srv.NewClient()
+	bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec, err := bo.NewQuery().Where("Cost").Between(10, 10).Select("AssetName").First(ctx, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.FieldValues["AssetName"] != "NB001" {
+		t.Errorf("First() = %v, want NB001", rec.FieldValues)
+	}
+	if _, ok := rec.FieldValues["Status"]; ok {
+		t.Errorf("First() = %v, want only the selected Fields and those needed by the Client", rec.FieldValues)
+	}
+}
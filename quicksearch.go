@@ -0,0 +1,82 @@
+package gocherwell
+
+import (
+	"context"
+	"fmt"
+)
+
+// quickSearchRequest is used to Marshal the HTTP-Request of getquicksearchresults.
+type quickSearchRequest struct {
+	BusObIDs   []string `json:"busObIds"`
+	SearchText string   `json:"searchText"`
+}
+
+// QuickSearch runs a free-text Search across the given BusinessObjects and returns the Results grouped by BusinessObject.
+// Without BusinessObjects Cherwell searches all BusinessObjects configured for the QuickSearch.
+func (cl *Client) QuickSearch(ctx context.Context, text string, busObs ...*BusinessObject) (*SimpleResults, error) {
+	req := quickSearchRequest{BusObIDs: []string{}, SearchText: text}
+	for _, bo := range busObs {
+		if bo == nil {
+			return nil, fmt.Errorf("%w: BusinessObject", ErrNilReceiver)
+		}
+		req.BusObIDs = append(req.BusObIDs, bo.BusObID)
+	}
+	res := SimpleResults{}
+	if err := cl.request(ctx, "POST", getQuickSearchResultsURI, nil, &req, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// QuickSearch runs a free-text Search across the BusinessObject and returns the Results.
+func (bo *BusinessObject) QuickSearch(ctx context.Context, cl *Client, text string) (*SimpleResults, error) {
+	if bo == nil {
+		return nil, fmt.Errorf("%w: BusinessObject", ErrNilReceiver)
+	}
+	return cl.QuickSearch(ctx, text, bo)
+}
+
+// Items returns the Items of all Groups of the SimpleResults.
+func (r *SimpleResults) Items() []SimpleResultsListItem {
+	if r == nil {
+		return nil
+	}
+	items := []SimpleResultsListItem{}
+	for _, g := range r.Groups {
+		items = append(items, g.SimpleResultsListItems...)
+	}
+	return items
+}
+
+// Records retreives the full BusinessObjectRecords of all Items of the SimpleResults and returns them.
+func (r *SimpleResults) Records(ctx context.Context, cl *Client) (*[]BusinessObjectRecord, error) {
+	records := []BusinessObjectRecord{}
+	for _, item := range r.Items() {
+		rec, err := item.Record(ctx, cl)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, *rec)
+	}
+	return &records, nil
+}
+
+// Records retreives the full BusinessObjectRecords of all Items of the SimpleResultsGroup and returns them.
+func (g *SimpleResultsGroup) Records(ctx context.Context, cl *Client) (*[]BusinessObjectRecord, error) {
+	if g == nil {
+		return nil, fmt.Errorf("%w: SimpleResultsGroup", ErrNilReceiver)
+	}
+	return (&SimpleResults{Groups: []SimpleResultsGroup{*g}}).Records(ctx, cl)
+}
+
+// Record retreives the full BusinessObjectRecord of the Item and returns it.
+func (item *SimpleResultsListItem) Record(ctx context.Context, cl *Client) (*BusinessObjectRecord, error) {
+	if item == nil {
+		return nil, fmt.Errorf("%w: SimpleResultsListItem", ErrNilReceiver)
+	}
+	bo := BusinessObject{BusObID: item.BusObID}
+	if item.BusObRecID != "" {
+		return bo.GetBusinessObjectRecordByRecID(ctx, cl, item.BusObRecID)
+	}
+	return bo.GetBusinessObjectRecordByPublicID(ctx, cl, item.PublicID)
+}
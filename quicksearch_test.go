@@ -0,0 +1,142 @@
+package gocherwell_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/itsscb/gocherwell"
+	"github.com/itsscb/gocherwell/cherwelltest"
+)
+
+// newQuickSearchServer returns a Server with the BusinessObject of testComputer and Incidents
+// INC-1 to INC-3, whose PublicID is their IncidentID. INC-1 and INC-3 mention Notebooks.
+func newQuickSearchServer() *cherwelltest.Server {
+	return cherwelltest.NewServer(testComputer(), cherwelltest.BusinessObject{
+		Name:          "Incident",
+		PublicIDField: "IncidentID",
+		Fields:        []cherwelltest.Field{{Name: "IncidentID"}, {Name: "Description"}},
+		Records: []map[string]string{
+			{"IncidentID": "INC-1", "Description": "NB001 does not boot"},
+			{"IncidentID": "INC-2", "Description": "Printer jammed"},
+			{"IncidentID": "INC-3", "Description": "Display of NB007 broken"},
+		},
+	})
+}
+
+func TestQuickSearch(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		busObs []string
+		groups []string
+	}{
+		{"all business objects", "nb00", nil, []string{"Computer:10", "Incident:2"}},
+		{"given business object", "nb00", []string{"Incident"}, []string{"Incident:2"}},
+		{"several business objects", "NB001", []string{"Incident", "Computer"}, []string{"Incident:1", "Computer:1"}},
+		{"single group", "printer", nil, []string{"Incident:1"}},
+		{"nothing found", "scanner", nil, []string{}},
+	}
+	srv := newQuickSearchServer()
+	defer srv.Close()
+	ctx := context.Background()
+	cl := srv.NewClient()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			busObs := []*gocherwell.BusinessObject{}
+			for _, name := range tt.busObs {
+				bo, err := cl.ResolveBusinessObject(ctx, name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				busObs = append(busObs, bo)
+			}
+			res, err := cl.QuickSearch(ctx, tt.text, busObs...)
+			if err != nil {
+				t.Fatalf("QuickSearch() error = %v", err)
+			}
+			groups := []string{}
+			n := 0
+			for _, g := range res.Groups {
+				groups = append(groups, fmt.Sprintf("%v:%v", g.Title, len(g.SimpleResultsListItems)))
+				n += len(g.SimpleResultsListItems)
+				for _, item := range g.SimpleResultsListItems {
+					if item.BusObID != g.TargetID {
+						t.Errorf("Item %v of BusinessObject %v in Group %v", item.PublicID, item.BusObID, g.TargetID)
+					}
+				}
+			}
+			if strings.Join(groups, ",") != strings.Join(tt.groups, ",") || len(res.Items()) != n {
+				t.Errorf("QuickSearch() = %v with %v Items, want %v", groups, len(res.Items()), tt.groups)
+			}
+		})
+	}
+
+	bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res, err := bo.QuickSearch(ctx, cl, "nb00"); err != nil || len(res.Groups) != 1 || res.Groups[0].TargetID != bo.BusObID {
+		t.Errorf("BusinessObject.QuickSearch() = %+v, %v, want only the Group of Computer", res, err)
+	}
+	if _, err := cl.QuickSearch(ctx, "nb00", bo, nil); !errors.Is(err, gocherwell.ErrNilReceiver) {
+		t.Errorf("QuickSearch() with nil BusinessObject error = %v, want ErrNilReceiver", err)
+	}
+}
+
+func TestQuickSearchRecords(t *testing.T) {
+	srv := newQuickSearchServer()
+	defer srv.Close()
+	ctx := context.Background()
+	cl := srv.NewClient()
+	res, err := cl.QuickSearch(ctx, "nb00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	incidents := res.Groups[1]
+	first := incidents.SimpleResultsListItems[0]
+
+	tests := []struct {
+		name       string
+		busObRecID string
+		publicID   string
+		want       string
+		err        error
+	}{
+		{"by rec id", first.BusObRecID, "", "INC-1", nil},
+		{"rec id takes precedence", first.BusObRecID, "INC-3", "INC-1", nil},
+		{"by public id", "", "INC-3", "INC-3", nil},
+		{"missing rec id", "missing", "", "", gocherwell.ErrNotFound},
+		{"missing public id", "", "INC-9", "", gocherwell.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := first
+			item.BusObRecID, item.PublicID = tt.busObRecID, tt.publicID
+			rec, err := item.Record(ctx, cl)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Record() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && (rec.FieldValues["IncidentID"] != tt.want || len(rec.Fields) == 0) {
+				t.Errorf("Record() = %v with %v Fields, want %v with all Fields", rec.FieldValues["IncidentID"], len(rec.Fields), tt.want)
+			}
+		})
+	}
+
+	recs, err := res.Records(ctx, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*recs) != 12 {
+		t.Errorf("SimpleResults.Records() = %v Records, want 12", len(*recs))
+	}
+	recs, err = incidents.Records(ctx, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint((*recs)[0].FieldValues["Description"], ", ", (*recs)[1].FieldValues["Description"]); got != "NB001 does not boot, Display of NB007 broken" {
+		t.Errorf("SimpleResultsGroup.Records() = %v, want INC-1 and INC-3", got)
+	}
+}
@@ -0,0 +1,244 @@
+package gocherwell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// TeamSettings configures how GetTeamMembers finds a Team and its Members. BusinessObjects and Fields are
+// given by internal Name, which unlike the DisplayName does not depend on the Language of the Cherwell Server.
+// Empty Values are taken from the Defaults.
+type TeamSettings struct {
+	// BusinessObject of the Teams. Default is OrganizationalUnit.
+	BusinessObject string
+	// TypeField and TypeValue select the Teams among the Records of BusinessObject. Default is Type and Team.
+	TypeField string
+	TypeValue string
+	// NameField contains the Name of a Team. Default is FullName.
+	NameField string
+	// MemberBusinessObject is the Target of the Relationship to the Members. Default is Contact.
+	MemberBusinessObject string
+	// MembersRelationship is the RelationshipID of the Relationship to the Members. It is only needed if
+	// BusinessObject has several Relationships to MemberBusinessObject.
+	MembersRelationship string
+}
+
+// defaultTeamSettings are used for all TeamSettings not given with WithTeamSettings.
+var defaultTeamSettings = TeamSettings{
+	BusinessObject:       "OrganizationalUnit",
+	TypeField:            "Type",
+	TypeValue:            "Team",
+	NameField:            "FullName",
+	MemberBusinessObject: "Contact",
+}
+
+// WithTeamSettings sets the TeamSettings used by GetTeamMembers, e.g. for Servers with a customized Team-BusinessObject.
+func WithTeamSettings(s TeamSettings) Option {
+	return func(o *options) {
+		o.teamSettings = s
+	}
+}
+
+// teamSettings returns the TeamSettings of the Client with defaults applied
+func (cl *Client) teamSettings() TeamSettings {
+	s, def := cl.team, defaultTeamSettings
+	if s.BusinessObject == "" {
+		s.BusinessObject = def.BusinessObject
+	}
+	if s.TypeField == "" {
+		s.TypeField = def.TypeField
+	}
+	if s.TypeValue == "" {
+		s.TypeValue = def.TypeValue
+	}
+	if s.NameField == "" {
+		s.NameField = def.NameField
+	}
+	if s.MemberBusinessObject == "" {
+		s.MemberBusinessObject = def.MemberBusinessObject
+	}
+	return s
+}
+
+// ResolveBusinessObject retreives a Cherwell BusinessObject by BusObID, internal Name or DisplayName and returns it.
+// The internal Name is looked up directly with GetBusinessObjectByName. If it is not found, the Summaries of all
+// BusinessObjects are searched for the BusObID first, then the Name and the DisplayName last.
+func (cl *Client) ResolveBusinessObject(ctx context.Context, id string) (*BusinessObject, error) {
+	if id != "" {
+		bo, err := cl.GetBusinessObjectByName(ctx, id)
+		if err == nil {
+			return bo, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+	}
+	res, err := cl.getBusinessObjectSummaries(ctx, BusinessObjectTypeAll)
+	if err != nil {
+		return nil, err
+	}
+	objects := []BusinessObject{}
+	for _, b := range res {
+		objects = append(objects, b)
+		objects = append(objects, b.GroupSummaries...)
+	}
+	matchers := []func(b BusinessObject) bool{
+		func(b BusinessObject) bool { return strings.EqualFold(b.BusObID, id) },
+		func(b BusinessObject) bool { return strings.EqualFold(b.Name, id) },
+		func(b BusinessObject) bool { return b.DisplayName == id },
+	}
+	for _, match := range matchers {
+		for i := range objects {
+			if match(objects[i]) {
+				return &objects[i], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: BusinessObject: %v", ErrNotFound, id)
+}
+
+// FieldDefinition returns the FieldDefinition with the given FieldID, FullFieldID, internal Name or DisplayName.
+func (sch *BusinessObjectSchema) FieldDefinition(id string) (*FieldDefinition, error) {
+	if sch == nil {
+		return nil, fmt.Errorf("%w: BusinessObjectSchema", ErrNilReceiver)
+	}
+	i := resolve(len(sch.FieldDefinitions), id, func(i int) (string, string, string) {
+		f := sch.FieldDefinitions[i]
+		return fullFieldID(sch.BusObID, f.FieldID), f.Name, f.DisplayName
+	})
+	if i < 0 {
+		return nil, fmt.Errorf("%w: Field: %v", ErrNotFound, id)
+	}
+	return &sch.FieldDefinitions[i], nil
+}
+
+// RelationshipTo returns the Relationship to the BusinessObject with the given BusObID. Unlike the DisplayName
+// of a Relationship, the BusObID of its Target does not depend on the Language of the Cherwell Server.
+// If there are several, only one with Cardinality Many is accepted.
+func (sch *BusinessObjectSchema) RelationshipTo(busObID string) (*Relationship, error) {
+	if sch == nil {
+		return nil, fmt.Errorf("%w: BusinessObjectSchema", ErrNilReceiver)
+	}
+	found := []int{}
+	for i, r := range sch.Relationships {
+		if sameID(r.Target, busObID) {
+			found = append(found, i)
+		}
+	}
+	if len(found) > 1 {
+		many := []int{}
+		for _, i := range found {
+			if strings.EqualFold(sch.Relationships[i].Cardinality, "Many") {
+				many = append(many, i)
+			}
+		}
+		found = many
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("%w: Relationship to BusinessObject: %v", ErrNotFound, busObID)
+	case 1:
+		return &sch.Relationships[found[0]], nil
+	}
+	ids := []string{}
+	for _, i := range found {
+		ids = append(ids, sch.Relationships[i].RelationshipID+" ("+sch.Relationships[i].DisplayName+")")
+	}
+	return nil, fmt.Errorf("%w: Relationship to BusinessObject: %v: %v", ErrMultipleResults, busObID, strings.Join(ids, ", "))
+}
+
+// Relationship returns the Relationship with the given RelationshipID or DisplayName.
+// The Cherwell API does not expose internal Names of Relationships, so the RelationshipID
+// is the only Language independent Identifier.
+func (sch *BusinessObjectSchema) Relationship(id string) (*Relationship, error) {
+	if sch == nil {
+		return nil, fmt.Errorf("%w: BusinessObjectSchema", ErrNilReceiver)
+	}
+	i := resolve(len(sch.Relationships), id, func(i int) (string, string, string) {
+		r := sch.Relationships[i]
+		return r.RelationshipID, "", r.DisplayName
+	})
+	if i < 0 {
+		return nil, fmt.Errorf("%w: Relationship: %v", ErrNotFound, id)
+	}
+	return &sch.Relationships[i], nil
+}
+
+// Field returns the Field with the given FieldID, FullFieldID, internal Name or DisplayName.
+func (t *BusinessObjectTemplate) Field(id string) (*Field, error) {
+	if t == nil {
+		return nil, fmt.Errorf("%w: BusinessObjectTemplate", ErrNilReceiver)
+	}
+	i := resolveField(t.Fields, id)
+	if i < 0 {
+		return nil, fmt.Errorf("%w: Field: %v", ErrNotFound, id)
+	}
+	return &t.Fields[i], nil
+}
+
+// Field returns the Field with the given FieldID, FullFieldID, internal Name or DisplayName.
+func (rec *BusinessObjectRecord) Field(id string) (*Field, error) {
+	if rec == nil {
+		return nil, fmt.Errorf("%w: BusinessObjectRecord", ErrNilReceiver)
+	}
+	i := resolveField(rec.Fields, id)
+	if i < 0 {
+		return nil, fmt.Errorf("%w: Field: %v", ErrNotFound, id)
+	}
+	return &rec.Fields[i], nil
+}
+
+// resolveField returns the Index of the Field with the given FieldID, FullFieldID, Name or DisplayName or -1
+func resolveField(fields []Field, id string) int {
+	return resolve(len(fields), id, func(i int) (string, string, string) {
+		f := fields[i]
+		if f.FullFieldID != "" {
+			return f.FullFieldID, f.Name, f.DisplayName
+		}
+		return f.FieldID, f.Name, f.DisplayName
+	})
+}
+
+// resolve returns the Index of the first of n Elements whose ID, Name or DisplayName equals id or -1.
+// IDs are compared first, then Names and DisplayNames last, so a DisplayName can not shadow a Name.
+func resolve(n int, id string, element func(i int) (elemID, name, displayName string)) int {
+	for i := 0; i < n; i++ {
+		if elemID, _, _ := element(i); elemID != "" && sameID(elemID, id) {
+			return i
+		}
+	}
+	for i := 0; i < n; i++ {
+		if _, name, _ := element(i); name != "" && strings.EqualFold(name, id) {
+			return i
+		}
+	}
+	for i := 0; i < n; i++ {
+		if _, _, displayName := element(i); displayName == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// fullFieldID returns the FullFieldID of a Field, FieldIDs that are already full are returned unchanged
+func fullFieldID(busObID, fieldID string) string {
+	if strings.HasPrefix(fieldID, "BO:") || busObID == "" {
+		return fieldID
+	}
+	return "BO:" + busObID + ",FI:" + fieldID
+}
+
+// sameID reports whether two FieldIDs, FullFieldIDs or other IDs identify the same Element
+func sameID(a, b string) bool {
+	return strings.EqualFold(a, b) || strings.EqualFold(shortID(a), shortID(b))
+}
+
+// shortID returns the FieldID of a FullFieldID like "BO:<BusObID>,FI:<FieldID>" or the ID unchanged
+func shortID(id string) string {
+	if i := strings.Index(id, ",FI:"); i >= 0 && strings.HasPrefix(id, "BO:") {
+		return id[i+len(",FI:"):]
+	}
+	return id
+}
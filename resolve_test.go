@@ -0,0 +1,250 @@
+package gocherwell_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/itsscb/gocherwell"
+	"github.com/itsscb/gocherwell/cherwelltest"
+)
+
+// newTeamServer returns a Server with German DisplayNames, the Teams Service Desk and Network of the
+// default TeamSettings and the Squad Blue of a customized Team-BusinessObject.
+func newTeamServer(t *testing.T) *cherwelltest.Server {
+	t.Helper()
+	srv := cherwelltest.NewServer(
+		cherwelltest.BusinessObject{
+			BusObID: "BOContact", Name: "Contact", DisplayName: "Kontakt",
+			Fields:  []cherwelltest.Field{{Name: "FullName", DisplayName: "Vollständiger Name"}},
+			Records: []map[string]string{{"FullName": "Alice"}, {"FullName": "Bob"}, {"FullName": "Carol"}},
+		},
+		cherwelltest.BusinessObject{
+			BusObID: "BOOrgUnit", Name: "OrganizationalUnit", DisplayName: "Organisationseinheit", PublicIDField: "FullName",
+			Fields: []cherwelltest.Field{{Name: "FullName", DisplayName: "Vollständiger Name"}, {Name: "Type", DisplayName: "Typ"}},
+			Relationships: []cherwelltest.Relationship{
+				{RelationshipID: "REMembers", Name: "Members", DisplayName: "Mitglieder", Target: "BOContact"},
+				{RelationshipID: "REManager", Name: "Manager", DisplayName: "Leitung", Target: "BOContact", Cardinality: "One"},
+			},
+			Records: []map[string]string{
+				{"FullName": "Service Desk", "Type": "Team"},
+				{"FullName": "Network", "Type": "Team"},
+				{"FullName": "IT", "Type": "Department"},
+			},
+		},
+		cherwelltest.BusinessObject{
+			BusObID: "BOSquad", Name: "Squad", DisplayName: "Staffel", PublicIDField: "Title",
+			Fields: []cherwelltest.Field{{Name: "Title", DisplayName: "Titel"}, {Name: "Kind", DisplayName: "Art"}},
+			Relationships: []cherwelltest.Relationship{
+				{RelationshipID: "RESquadMembers", Name: "SquadMembers", DisplayName: "Mitglieder", Target: "BOContact"},
+				{RelationshipID: "RESquadGuests", Name: "SquadGuests", DisplayName: "Gäste", Target: "BOContact"},
+			},
+			Records: []map[string]string{{"Title": "Blue", "Kind": "Squad"}},
+		},
+	)
+	ctx := context.Background()
+	cl := srv.NewClient()
+	link := func(busOb, field, parent, relationship string, children ...string) {
+		bo, err := cl.ResolveBusinessObject(ctx, busOb)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p, err := bo.NewQuery().Where(field).Eq(parent).One(ctx, cl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		contact, err := cl.ResolveBusinessObject(ctx, "Contact")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range children {
+			c, err := contact.NewQuery().Where("FullName").Eq(name).One(ctx, cl)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := p.LinkBusinessObjectRecord(ctx, cl, c, relationship); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	link("OrganizationalUnit", "FullName", "Service Desk", "REMembers", "Alice", "Bob")
+	link("OrganizationalUnit", "FullName", "Service Desk", "REManager", "Carol")
+	link("OrganizationalUnit", "FullName", "Network", "REMembers", "Carol")
+	link("Squad", "Title", "Blue", "RESquadMembers", "Carol")
+	link("Squad", "Title", "Blue", "RESquadGuests", "Alice")
+	return srv
+}
+
+func TestGetTeamMembers(t *testing.T) {
+	squad := gocherwell.TeamSettings{BusinessObject: "Squad", TypeField: "Kind", TypeValue: "Squad", NameField: "Title"}
+	withMembers := squad
+	withMembers.MembersRelationship = "RESquadMembers"
+	tests := []struct {
+		name     string
+		settings gocherwell.TeamSettings
+		team     string
+		want     []string
+		err      error
+	}{
+		{"default settings", gocherwell.TeamSettings{}, "Service Desk", []string{"Alice", "Bob"}, nil},
+		{"other team", gocherwell.TeamSettings{}, "Network", []string{"Carol"}, nil},
+		{"department is no team", gocherwell.TeamSettings{}, "IT", nil, gocherwell.ErrNotFound},
+		{"unknown team", gocherwell.TeamSettings{}, "Missing", nil, gocherwell.ErrNotFound},
+		{"custom settings", withMembers, "Blue", []string{"Carol"}, nil},
+		{"ambiguous relationship", squad, "Blue", nil, gocherwell.ErrMultipleResults},
+		{"unknown business object", gocherwell.TeamSettings{BusinessObject: "Missing"}, "Blue", nil, gocherwell.ErrNotFound},
+	}
+	srv := newTeamServer(t)
+	defer srv.Close()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl := srv.NewClient(gocherwell.WithTeamSettings(tt.settings))
+			members, err := cl.GetTeamMembers(context.Background(), tt.team)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("GetTeamMembers() error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			names := []string{}
+			for _, m := range *members {
+				name, err := m.Values(nil).String("FullName")
+				if err != nil {
+					t.Fatal(err)
+				}
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			if strings.Join(names, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("GetTeamMembers() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveBusinessObject(t *testing.T) {
+	tests := []struct {
+		id   string
+		want string
+		err  error
+	}{
+		{"BOContact", "Contact", nil},
+		{"bocontact", "Contact", nil},
+		{"OrganizationalUnit", "OrganizationalUnit", nil},
+		{"Staffel", "Squad", nil},
+		{"Team", "", gocherwell.ErrNotFound},
+	}
+	srv := newTeamServer(t)
+	defer srv.Close()
+	cl := srv.NewClient()
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			bo, err := cl.ResolveBusinessObject(context.Background(), tt.id)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ResolveBusinessObject() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && bo.Name != tt.want {
+				t.Errorf("ResolveBusinessObject() = %v, want %v", bo.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelationshipTo(t *testing.T) {
+	tests := []struct {
+		busOb  string
+		target string
+		want   string
+		err    error
+	}{
+		{"OrganizationalUnit", "BOContact", "REMembers", nil},
+		{"OrganizationalUnit", "bocontact", "REMembers", nil},
+		{"Squad", "BOContact", "", gocherwell.ErrMultipleResults},
+		{"Squad", "BOOrgUnit", "", gocherwell.ErrNotFound},
+	}
+	srv := newTeamServer(t)
+	defer srv.Close()
+	ctx := context.Background()
+	cl := srv.NewClient()
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v to %v", tt.busOb, tt.target), func(t *testing.T) {
+			bo, err := cl.ResolveBusinessObject(ctx, tt.busOb)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sch, err := bo.GetBusinessObjectSchema(ctx, cl)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, err := sch.RelationshipTo(tt.target)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("RelationshipTo() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && r.RelationshipID != tt.want {
+				t.Errorf("RelationshipTo() = %v, want %v", r.RelationshipID, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveFieldValueKeys(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+		err  error
+	}{
+		{"Typ", "Group", nil},
+		{"Type", "Group", nil},
+		{"FIType", "Group", nil},
+		{"BO:BOOrgUnit,FI:FIType", "Group", nil},
+		{"Missing", "Team", gocherwell.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			srv := newTeamServer(t)
+			defer srv.Close()
+			ctx := context.Background()
+			cl := srv.NewClient()
+			bo, err := cl.ResolveBusinessObject(ctx, "OrganizationalUnit")
+			if err != nil {
+				t.Fatal(err)
+			}
+			rec, err := bo.NewQuery().Where("FullName").Eq("Network").One(ctx, cl)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rec.FieldValues[tt.key] = "Group"
+			if _, err := rec.SaveBusinessObjectRecord(ctx, cl); !errors.Is(err, tt.err) {
+				t.Fatalf("SaveBusinessObjectRecord() error = %v, want %v", err, tt.err)
+			}
+			if got := srv.Records("OrganizationalUnit")[1]["Type"]; got != tt.want {
+				t.Errorf("saved Type = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewBusinessObjectRecord(t *testing.T) {
+	srv := newTeamServer(t)
+	defer srv.Close()
+	ctx := context.Background()
+	cl := srv.NewClient()
+	bo, err := cl.ResolveBusinessObject(ctx, "OrganizationalUnit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = bo.NewBusinessObjectRecord(ctx, cl, []gocherwell.Field{
+		{Name: "FullName", Value: "Backoffice"},
+		{DisplayName: "Typ", Value: "Team"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := srv.Records("OrganizationalUnit")
+	if got := records[len(records)-1]; got["FullName"] != "Backoffice" || got["Type"] != "Team" {
+		t.Errorf("created Record = %v, want FullName Backoffice and Type Team", got)
+	}
+}
@@ -0,0 +1,168 @@
+package gocherwell
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy configures how failed HTTP-Requests to the Cherwell Server are retried.
+// Delays grow exponentially from BaseDelay up to MaxDelay with full jitter, a Retry-After
+// Header of the Response takes precedence but is limited to MaxDelay as well.
+// Independent of the RetryPolicy, a Request answered with 401 is retried once after re-authenticating
+// and Grants rejected by the token endpoint are never retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum Number of Attempts including the first one. 1 disables retries.
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	// Retryable decides if a failed Request is retried. StatusCode is 0 if no Response was received.
+	// Default is DefaultRetryable.
+	Retryable func(method, uri string, statusCode int, err error) bool
+}
+
+// DefaultRetryPolicy is used by Clients without a RetryPolicy.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   250 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+	Retryable:   DefaultRetryable,
+}
+
+// WithRetryPolicy sets the RetryPolicy of the Client.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = &p
+	}
+}
+
+// readOnlyPostURIs are Cherwell API URIs which use POST but do not change any Data.
+var readOnlyPostURIs = []string{
+	getSearchResultsURI,
+	getBusObTemplateURI,
+	getQuickSearchResultsURI,
+}
+
+// IsIdempotent reports whether a Request can be sent again without side effects.
+// Besides GET and DELETE this applies to POST Requests of Searches, Templates and
+// Tokens, but not to savebusinessobject.
+func IsIdempotent(method, uri string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		if u, err := url.Parse(uri); err == nil && (u.Path == tokenURI || strings.HasSuffix(u.Path, "/"+tokenURI)) {
+			return true
+		}
+		for _, u := range readOnlyPostURIs {
+			if strings.Contains(strings.ToLower(uri), strings.ToLower(u)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// DefaultRetryable retries idempotent Requests which failed with a network error,
+// 429 Too Many Requests or a transient 5xx Status.
+func DefaultRetryable(method, uri string, statusCode int, err error) bool {
+	if isContextError(err) || !IsIdempotent(method, uri) {
+		return false
+	}
+	switch statusCode {
+	case 0:
+		return err != nil
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryPolicy returns the RetryPolicy of the Client with defaults applied
+func (cl *Client) retryPolicy() RetryPolicy {
+	p := DefaultRetryPolicy
+	if cl.retry != nil {
+		p = *cl.retry
+	}
+	if p.MaxAttempts < 1 {
+		p.MaxAttempts = 1
+	}
+	if p.Retryable == nil {
+		p.Retryable = DefaultRetryable
+	}
+	return p
+}
+
+// backoff returns the Delay before the given Attempt (starting at 1 for the first retry).
+// A Retry-After of the Server is clamped to MaxDelay, so it can not stall the Caller
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if d, ok := retryAfter(resp); ok {
+		if p.MaxDelay > 0 && d > p.MaxDelay {
+			return p.MaxDelay
+		}
+		return d
+	}
+	d := p.BaseDelay << uint(attempt-1)
+	if d <= 0 || (p.MaxDelay > 0 && d > p.MaxDelay) {
+		d = p.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(d)) + 1)
+}
+
+// retryAfter parses the Retry-After Header of a Response as Seconds or HTTP-Date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// sleep waits for the given Duration or until the Context is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// statusCode returns the HTTP-Status of a failed Request or 0 if no Response was received
+func statusCode(resp *http.Response, err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	if resp != nil {
+		return resp.StatusCode
+	}
+	return 0
+}
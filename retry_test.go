@@ -0,0 +1,184 @@
+package gocherwell_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/itsscb/gocherwell"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	schema := func(ctx context.Context, cl *gocherwell.Client, bo *gocherwell.BusinessObject) error {
+		_, err := bo.GetBusinessObjectSchema(ctx, cl)
+		return err
+	}
+	save := func(ctx context.Context, cl *gocherwell.Client, bo *gocherwell.BusinessObject) error {
+		rec, err := bo.NewQuery().Where("AssetName").Eq("NB001").One(ctx, cl)
+		if err != nil {
+			return err
+		}
+		rec.FieldValues["Status"] = "Retired"
+		_, err = rec.SaveBusinessObjectRecord(ctx, cl)
+		return err
+	}
+	fast := gocherwell.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+	retryAfter := http.Header{"Retry-After": []string{"3600"}}
+
+	tests := []struct {
+		name     string
+		op       func(ctx context.Context, cl *gocherwell.Client, bo *gocherwell.BusinessObject) error
+		endpoint string
+		statuses []int
+		header   http.Header
+		policy   gocherwell.RetryPolicy
+		requests int
+		status   int
+	}{
+		{"transient error is retried", schema, "getbusinessobjectschema", []int{503}, nil, fast, 2, 0},
+		{"too many requests is retried", schema, "getbusinessobjectschema", []int{429, 502}, nil, fast, 3, 0},
+		{"retry-after is clamped to max delay", schema, "getbusinessobjectschema", []int{503}, retryAfter, fast, 2, 0},
+		{"attempts are limited", schema, "getbusinessobjectschema", []int{503, 503, 503}, nil, fast, 3, 503},
+		{"retries can be disabled", schema, "getbusinessobjectschema", []int{503}, nil, gocherwell.RetryPolicy{MaxAttempts: 1}, 1, 503},
+		{"client error is not retried", schema, "getbusinessobjectschema", []int{404}, nil, fast, 1, 404},
+		{"save is not retried", save, "savebusinessobject", []int{503}, nil, fast, 1, 503},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer()
+			defer srv.Close()
+			ctx := context.Background()
+			hooks := &recordingHooks{}
+			cl := srv.NewClient(gocherwell.WithRetryPolicy(tt.policy), gocherwell.WithHooks(hooks))
+			bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, status := range tt.statuses {
+				srv.FailNext(tt.endpoint, status, tt.header)
+			}
+
+			start := time.Now()
+			err = tt.op(ctx, cl, bo)
+			if d := time.Since(start); d > 5*time.Second {
+				t.Errorf("call took %v, want the Delays limited by MaxDelay", d)
+			}
+			var apiErr *gocherwell.APIError
+			switch {
+			case tt.status == 0 && err != nil:
+				t.Errorf("error = %v, want nil", err)
+			case tt.status != 0 && (!errors.As(err, &apiErr) || apiErr.StatusCode != tt.status):
+				t.Errorf("error = %v, want Status %v", err, tt.status)
+			}
+			if got := countRequests(srv, tt.endpoint); got != tt.requests {
+				t.Errorf("Requests = %v, want %v", got, tt.requests)
+			}
+			if res := hooks.results(tt.endpoint); len(res) != 1 || res[0].Retries != tt.requests-1 {
+				t.Errorf("Hooks = %+v, want 1 call with %v retries", res, tt.requests-1)
+			}
+		})
+	}
+}
+
+func TestRetryTokenRequests(t *testing.T) {
+	fast := gocherwell.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+	always := fast
+	always.Retryable = func(method, uri string, statusCode int, err error) bool { return true }
+
+	tests := []struct {
+		name     string
+		statuses []int
+		policy   gocherwell.RetryPolicy
+		requests int
+		status   int
+	}{
+		{"transient error is retried", []int{503}, fast, 2, 0},
+		{"too many requests is retried", []int{429, 502}, fast, 3, 0},
+		{"attempts are limited", []int{503, 503, 503}, fast, 3, 503},
+		{"rejected grant is not retried", []int{400}, fast, 1, 400},
+		{"unauthorized grant is not retried", []int{401}, fast, 1, 401},
+		{"rejected grant is not retried by any policy", []int{400}, always, 1, 400},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer()
+			defer srv.Close()
+			hooks := &recordingHooks{}
+			cl := srv.NewClient(gocherwell.WithRetryPolicy(tt.policy), gocherwell.WithHooks(hooks), gocherwell.WithMaxInFlight(1))
+			for _, status := range tt.statuses {
+				srv.FailNext("token", status, nil)
+			}
+			_, err := cl.Login(context.Background())
+			var apiErr *gocherwell.APIError
+			switch {
+			case tt.status == 0 && err != nil:
+				t.Errorf("Login() error = %v, want nil", err)
+			case tt.status != 0 && (!errors.As(err, &apiErr) || apiErr.StatusCode != tt.status):
+				t.Errorf("Login() error = %v, want Status %v", err, tt.status)
+			}
+			if got := len(grantTypes(srv)); got != tt.requests {
+				t.Errorf("token Requests = %v, want %v", got, tt.requests)
+			}
+			if res := hooks.results("token"); len(res) != 1 || res[0].Retries != tt.requests-1 {
+				t.Errorf("Hooks = %+v, want 1 call with %v retries", res, tt.requests-1)
+			}
+			if got := cl.Stats().Requests; got != uint64(tt.requests) {
+				t.Errorf("Stats().Requests = %v, want %v passing the Limiter", got, tt.requests)
+			}
+		})
+	}
+}
+
+func TestRetryAbortedByContext(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	cl := srv.NewClient(gocherwell.WithRetryPolicy(gocherwell.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour}))
+	bo, err := cl.ResolveBusinessObject(context.Background(), "Computer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.FailNext("getbusinessobjectschema", http.StatusServiceUnavailable, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = bo.GetBusinessObjectSchema(ctx, cl)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want context.DeadlineExceeded", err)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("call took %v, want it aborted with the Context", d)
+	}
+}
+
+func TestDefaultRetryable(t *testing.T) {
+	errNetwork := errors.New("connection reset")
+	tests := []struct {
+		method string
+		uri    string
+		status int
+		err    error
+		want   bool
+	}{
+		{"GET", "api/V1/getbusinessobjectschema/busobid/BO1", 503, errNetwork, true},
+		{"GET", "api/V1/getbusinessobjectschema/busobid/BO1", 0, errNetwork, true},
+		{"GET", "api/V1/getbusinessobjectschema/busobid/BO1", 429, errNetwork, true},
+		{"GET", "api/V1/getbusinessobjectschema/busobid/BO1", 404, errNetwork, false},
+		{"GET", "api/V1/getbusinessobjectschema/busobid/BO1", 0, context.Canceled, false},
+		{"DELETE", "api/V1/logout", 500, errNetwork, true},
+		{"POST", "api/V1/getsearchresults", 504, errNetwork, true},
+		{"POST", "api/V1/getbusinessobjecttemplate", 502, errNetwork, true},
+		{"POST", "api/V1/savebusinessobject", 503, errNetwork, false},
+		{"POST", "https://cherwell/CherwellAPI/token?auth_mode=Internal", 503, errNetwork, true},
+		{"POST", "https://cherwell/CherwellAPI/token", 400, errNetwork, false},
+		{"POST", "https://token/CherwellAPI/api/V1/savebusinessobject", 503, errNetwork, false},
+		{"PUT", "api/V1/anything", 503, errNetwork, false},
+	}
+	for _, tt := range tests {
+		if got := gocherwell.DefaultRetryable(tt.method, tt.uri, tt.status, tt.err); got != tt.want {
+			t.Errorf("DefaultRetryable(%v, %v, %v, %v) = %v, want %v", tt.method, tt.uri, tt.status, tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package gocherwell
+
+import "context"
+
+// MetadataService retreives the Definitions of Cherwell BusinessObjects.
+type MetadataService interface {
+	GetBusinessObjectByDisplayName(ctx context.Context, displayName string) (*BusinessObject, error)
+	GetBusinessObjectByBusObID(ctx context.Context, busObID string) (*BusinessObject, error)
+	GetBusinessObjectByName(ctx context.Context, name string) (*BusinessObject, error)
+	GetBusinessObjectsByType(ctx context.Context, typ BusinessObjectType) (*[]BusinessObject, error)
+	ResolveBusinessObject(ctx context.Context, id string) (*BusinessObject, error)
+	GetBusinessObjectSchema(ctx context.Context, bo *BusinessObject) (*BusinessObjectSchema, error)
+}
+
+// RecordService creates, reads, searches, saves and deletes Cherwell BusinessObjectRecords.
+type RecordService interface {
+	GetBusinessObjectRecordByPublicID(ctx context.Context, bo *BusinessObject, publicID string) (*BusinessObjectRecord, error)
+	GetBusinessObjectRecordByRecID(ctx context.Context, bo *BusinessObject, recID string) (*BusinessObjectRecord, error)
+	NewBusinessObjectRecord(ctx context.Context, bo *BusinessObject, fields []Field) (*BusinessObjectRecord, error)
+	SaveBusinessObjectRecord(ctx context.Context, rec *BusinessObjectRecord) (*BusinessObjectRecord, error)
+	DeleteBusinessObjectRecord(ctx context.Context, rec *BusinessObjectRecord) (*BusinessObjectRecord, error)
+	SearchBusinessObjectRecord(ctx context.Context, bo *BusinessObject, filters ...[]string) (*BusinessObjectRecord, error)
+	SearchOneBusinessObjectRecord(ctx context.Context, bo *BusinessObject, filters ...[]string) (*BusinessObjectRecord, error)
+	SearchMultipleBusinessObjectRecords(ctx context.Context, bo *BusinessObject, filters ...[]string) (*[]BusinessObjectRecord, error)
+	QuickSearch(ctx context.Context, text string, busObs ...*BusinessObject) (*SimpleResults, error)
+}
+
+// RelationshipService reads, links and unlinks related Cherwell BusinessObjectRecords.
+type RelationshipService interface {
+	GetRelatedBusinessObjects(ctx context.Context, rec *BusinessObjectRecord, relationshipName string) (*[]BusinessObjectRecord, error)
+	LinkBusinessObjectRecord(ctx context.Context, parent, child *BusinessObjectRecord, relationshipName string) error
+	UnlinkBusinessObjectRecord(ctx context.Context, parent, child *BusinessObjectRecord, relationshipName string) error
+}
+
+// Service combines all Services of the Cherwell API. It is implemented by *Client and
+// can be replaced by a mock like cherwellmock.Mock in tests.
+type Service interface {
+	MetadataService
+	RecordService
+	RelationshipService
+}
+
+var _ Service = (*Client)(nil)
+
+// GetBusinessObjectSchema retreives the Schema of the given BusinessObject and returns it
+func (cl *Client) GetBusinessObjectSchema(ctx context.Context, bo *BusinessObject) (*BusinessObjectSchema, error) {
+	return bo.GetBusinessObjectSchema(ctx, cl)
+}
+
+// GetBusinessObjectRecordByPublicID retreives a BusinessObjectRecord of the given BusinessObject by PublicID and returns it
+func (cl *Client) GetBusinessObjectRecordByPublicID(ctx context.Context, bo *BusinessObject, publicID string) (*BusinessObjectRecord, error) {
+	return bo.GetBusinessObjectRecordByPublicID(ctx, cl, publicID)
+}
+
+// GetBusinessObjectRecordByRecID retreives a BusinessObjectRecord of the given BusinessObject by RecID and returns it
+func (cl *Client) GetBusinessObjectRecordByRecID(ctx context.Context, bo *BusinessObject, recID string) (*BusinessObjectRecord, error) {
+	return bo.GetBusinessObjectRecordByRecID(ctx, cl, recID)
+}
+
+// NewBusinessObjectRecord creates a BusinessObjectRecord of the given BusinessObject with the given Fields and returns it
+func (cl *Client) NewBusinessObjectRecord(ctx context.Context, bo *BusinessObject, fields []Field) (*BusinessObjectRecord, error) {
+	return bo.NewBusinessObjectRecord(ctx, cl, fields)
+}
+
+// SaveBusinessObjectRecord saves the Changes of the given BusinessObjectRecord and returns the Response
+func (cl *Client) SaveBusinessObjectRecord(ctx context.Context, rec *BusinessObjectRecord) (*BusinessObjectRecord, error) {
+	return rec.SaveBusinessObjectRecord(ctx, cl)
+}
+
+// DeleteBusinessObjectRecord deletes the given BusinessObjectRecord and returns the Response
+func (cl *Client) DeleteBusinessObjectRecord(ctx context.Context, rec *BusinessObjectRecord) (*BusinessObjectRecord, error) {
+	return rec.DeleteBusinesObjectRecord(ctx, cl)
+}
+
+// SearchBusinessObjectRecord searches a BusinessObjectRecord of the given BusinessObject and returns the first Hit
+func (cl *Client) SearchBusinessObjectRecord(ctx context.Context, bo *BusinessObject, filters ...[]string) (*BusinessObjectRecord, error) {
+	return bo.SearchBusinessObjectRecord(ctx, cl, filters...)
+}
+
+// SearchOneBusinessObjectRecord searches the only BusinessObjectRecord of the given BusinessObject matching the Filters
+func (cl *Client) SearchOneBusinessObjectRecord(ctx context.Context, bo *BusinessObject, filters ...[]string) (*BusinessObjectRecord, error) {
+	return bo.SearchOneBusinessObjectRecord(ctx, cl, filters...)
+}
+
+// SearchMultipleBusinessObjectRecords searches BusinessObjectRecords of the given BusinessObject and returns all Hits
+func (cl *Client) SearchMultipleBusinessObjectRecords(ctx context.Context, bo *BusinessObject, filters ...[]string) (*[]BusinessObjectRecord, error) {
+	return bo.SearchMultipleBusinessObjectRecords(ctx, cl, filters...)
+}
+
+// GetRelatedBusinessObjects retreives the BusinessObjectRecords related to the given one by the named Relationship and returns them
+func (cl *Client) GetRelatedBusinessObjects(ctx context.Context, rec *BusinessObjectRecord, relationshipName string) (*[]BusinessObjectRecord, error) {
+	return rec.GetRelatedBusinessObjects(ctx, cl, relationshipName)
+}
+
+// LinkBusinessObjectRecord links the child to the parent BusinessObjectRecord by the named Relationship
+func (cl *Client) LinkBusinessObjectRecord(ctx context.Context, parent, child *BusinessObjectRecord, relationshipName string) error {
+	return parent.LinkBusinessObjectRecord(ctx, cl, child, relationshipName)
+}
+
+// UnlinkBusinessObjectRecord unlinks the child from the parent BusinessObjectRecord by the named Relationship
+func (cl *Client) UnlinkBusinessObjectRecord(ctx context.Context, parent, child *BusinessObjectRecord, relationshipName string) error {
+	return parent.UnlinkBusinessObjectRecord(ctx, cl, child, relationshipName)
+}
@@ -0,0 +1,28 @@
+package gocherwell
+
+import (
+	"context"
+)
+
+// Logout revokes the AccessToken at the Cherwell Server, clears the Token state and closes the Client.
+// Every subsequent call of the Client fails with ErrClientClosed. Calling Logout on a closed Client is a no-op.
+// The Logout passes the Limiter, RetryPolicy and Hooks of the Client like every other Request.
+func (cl *Client) Logout(ctx context.Context) error {
+	t, closed := cl.tokens.close()
+	if closed || t == nil || t.AccessToken == "" {
+		return nil
+	}
+	err := cl.requestWithToken(ctx, t.AccessToken, "DELETE", logoutURI, nil, nil, nil)
+	// A revoked Token must not be reused from a cache like FileTokenSource.
+	if c, ok := cl.tokenSource().(interface{ Clear() error }); ok {
+		if cerr := c.Clear(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// Close logs out of the Cherwell Server, see Logout. It implements io.Closer.
+func (cl *Client) Close() error {
+	return cl.Logout(context.Background())
+}
@@ -0,0 +1,64 @@
+package gocherwell_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/itsscb/gocherwell"
+	"github.com/itsscb/gocherwell/cherwelltest"
+)
+
+func TestLogout(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	ctx := context.Background()
+	hooks := &recordingHooks{}
+	cl := srv.NewClient(gocherwell.WithHooks(hooks), gocherwell.WithMaxInFlight(1))
+	bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	accessToken := cl.Token().AccessToken
+	before := cl.Stats().Requests
+
+	// The first Logout fails and is retried by the RetryPolicy.
+	srv.FailNext("logout", http.StatusServiceUnavailable, http.Header{"Retry-After": []string{"0"}})
+	if err := cl.Logout(ctx); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+	if res := hooks.results("logout"); len(res) != 1 || res[0].Retries != 1 || res[0].Err != nil {
+		t.Errorf("Logout() Hooks = %+v, want 1 successful call with 1 retry", res)
+	}
+	if got := cl.Stats().Requests - before; got != 2 {
+		t.Errorf("Stats().Requests = %v, want 2 more after Logout", got)
+	}
+	var logouts []cherwelltest.Request
+	for _, r := range srv.Requests() {
+		if strings.Contains(r.Path, "logout") {
+			logouts = append(logouts, r)
+		}
+	}
+	if len(logouts) != 2 {
+		t.Errorf("logout Requests = %v, want 2", len(logouts))
+	}
+	if cl.Token() != nil {
+		t.Errorf("Token() = %v, want nil", cl.Token())
+	}
+
+	if _, err := bo.NewQuery().Count(ctx, cl); !errors.Is(err, gocherwell.ErrClientClosed) {
+		t.Errorf("Count() after Logout error = %v, want ErrClientClosed", err)
+	}
+	if err := cl.Logout(ctx); err != nil {
+		t.Errorf("second Logout() error = %v, want nil", err)
+	}
+
+	// The revoked AccessToken is rejected by the Server.
+	other := srv.NewClient(gocherwell.WithTokenSource(gocherwell.StaticTokenSource(&gocherwell.Token{AccessToken: accessToken})),
+		gocherwell.WithRetryPolicy(gocherwell.RetryPolicy{MaxAttempts: 1}))
+	if _, err := other.ResolveBusinessObject(ctx, "Computer"); !errors.Is(err, gocherwell.ErrUnauthorized) {
+		t.Errorf("ResolveBusinessObject() with revoked Token error = %v, want ErrUnauthorized", err)
+	}
+}
@@ -0,0 +1,202 @@
+package gocherwell
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SearchFolder contains a Folder of the Tree of stored Searches in Cherwell.
+type SearchFolder struct {
+	Association        string         `json:"association,omitempty"`
+	ChildFolders       []SearchFolder `json:"childFolders,omitempty"`
+	ChildItems         []SearchItem   `json:"childItems,omitempty"`
+	ID                 string         `json:"id,omitempty"`
+	LocalizedScopeName string         `json:"localizedScopeName,omitempty"`
+	Name               string         `json:"name,omitempty"`
+	ParentFolderID     string         `json:"parentFolderId,omitempty"`
+	Scope              string         `json:"scope,omitempty"`
+	ScopeOwner         string         `json:"scopeOwner,omitempty"`
+}
+
+// SearchItem contains a Search stored in Cherwell. Its Association is the BusObID of the searched BusinessObject.
+type SearchItem struct {
+	Association        string `json:"association,omitempty"`
+	Description        string `json:"description,omitempty"`
+	DisplayName        string `json:"displayName,omitempty"`
+	ID                 string `json:"id,omitempty"`
+	LocalizedScopeName string `json:"localizedScopeName,omitempty"`
+	Name               string `json:"name,omitempty"`
+	ParentFolderID     string `json:"parentFolderId,omitempty"`
+	Scope              string `json:"scope,omitempty"`
+	ScopeOwner         string `json:"scopeOwner,omitempty"`
+}
+
+// searchItemsResult is used to Unmarshal the HTTP-Response of getsearchitems.
+type searchItemsResult struct {
+	Error
+	Root SearchFolder `json:"root"`
+}
+
+// GetSearchItems retreives the Tree of all stored Searches and returns its Root.
+func (cl *Client) GetSearchItems(ctx context.Context) (*SearchFolder, error) {
+	res := searchItemsResult{}
+	if err := cl.request(ctx, "GET", getSearchItemsURI, nil, nil, &res); err != nil {
+		return nil, err
+	}
+	return &res.Root, nil
+}
+
+// GetSearchItems retreives the Tree of the stored Searches of a BusinessObject and returns its Root.
+func (bo *BusinessObject) GetSearchItems(ctx context.Context, cl *Client) (*SearchFolder, error) {
+	if bo == nil {
+		return nil, fmt.Errorf("%w: BusinessObject", ErrNilReceiver)
+	}
+	res := searchItemsResult{}
+	if err := cl.request(ctx, "GET", getSearchItemsByBusObURI, busObIDValues(bo.BusObID), nil, &res); err != nil {
+		return nil, err
+	}
+	return &res.Root, nil
+}
+
+// GetStoredSearch retreives the stored Search of a BusinessObject with the given ID, Name or DisplayName and returns it.
+// An empty Scope matches every Scope, e.g. Global, Team or User.
+func (bo *BusinessObject) GetStoredSearch(ctx context.Context, cl *Client, id, scope string) (*SearchItem, error) {
+	root, err := bo.GetSearchItems(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+	return root.SearchItem(id, scope)
+}
+
+// Items returns the SearchItems of the SearchFolder and all its Subfolders.
+func (f *SearchFolder) Items() []SearchItem {
+	if f == nil {
+		return nil
+	}
+	items := append([]SearchItem{}, f.ChildItems...)
+	for i := range f.ChildFolders {
+		items = append(items, f.ChildFolders[i].Items()...)
+	}
+	return items
+}
+
+// SearchItem returns the SearchItem of the SearchFolder or its Subfolders with the given ID, Name or DisplayName.
+// An empty Scope matches every Scope.
+func (f *SearchFolder) SearchItem(id, scope string) (*SearchItem, error) {
+	if f == nil {
+		return nil, fmt.Errorf("%w: SearchFolder", ErrNilReceiver)
+	}
+	items := []SearchItem{}
+	for _, item := range f.Items() {
+		if scope == "" || strings.EqualFold(item.Scope, scope) {
+			items = append(items, item)
+		}
+	}
+	i := resolve(len(items), id, func(i int) (string, string, string) {
+		return items[i].ID, items[i].Name, items[i].DisplayName
+	})
+	if i < 0 {
+		return nil, fmt.Errorf("%w: Search: %v (Scope: %v)", ErrNotFound, id, scope)
+	}
+	return &items[i], nil
+}
+
+// Prompts retreives the Prompts of the stored Search, which ask for Values before the Search is run.
+func (s *SearchItem) Prompts(ctx context.Context, cl *Client) ([]Prompt, error) {
+	if s == nil {
+		return nil, fmt.Errorf("%w: SearchItem", ErrNilReceiver)
+	}
+	search := s.search()
+	search.PageNumber = 1
+	search.PageSize = 1
+	res := SearchResult{}
+	if err := cl.request(ctx, "POST", getSearchResultsURI, busObIDValues(s.Association), &search, &res); err != nil {
+		return nil, err
+	}
+	if res.Prompts == nil {
+		return []Prompt{}, nil
+	}
+	return res.Prompts, nil
+}
+
+// Records runs the stored Search and returns all BusinessObjectRecords found, Page by Page.
+// The Values of its Prompts are given by PromptID or Text, Prompts without Value use their Default.
+func (s *SearchItem) Records(ctx context.Context, cl *Client, values map[string]interface{}) (*[]BusinessObjectRecord, error) {
+	records := []BusinessObjectRecord{}
+	it := s.Iterator(ctx, cl, values)
+	for it.Next() {
+		records = append(records, *it.Record())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return &records, nil
+}
+
+// Iterator returns an Iterator over the BusinessObjectRecords found by the stored Search.
+// The Values of its Prompts are given by PromptID or Text like for Records. The Prompts and
+// the first Page are retreived by the first call of Next.
+func (s *SearchItem) Iterator(ctx context.Context, cl *Client, values map[string]interface{}) *Iterator {
+	return newIterator(ctx, cl, 0, false, func(ctx context.Context) (*compiledQuery, error) {
+		if s == nil {
+			return nil, fmt.Errorf("%w: SearchItem", ErrNilReceiver)
+		}
+		prompts, err := s.Prompts(ctx, cl)
+		if err != nil {
+			return nil, err
+		}
+		c := &compiledQuery{search: s.search()}
+		c.search.PromptValues, err = promptValues(prompts, values)
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	})
+}
+
+// search returns the Search running the stored Search
+func (s *SearchItem) search() Search {
+	return Search{
+		Association:      s.Association,
+		BusObID:          s.Association,
+		IncludeAllFields: true,
+		Scope:            s.Scope,
+		ScopeOwner:       s.ScopeOwner,
+		SearchID:         s.ID,
+	}
+}
+
+// promptValues assigns the given Values by PromptID or Text to the Prompts and returns them as PromptValues
+func promptValues(prompts []Prompt, values map[string]interface{}) ([]PromptValue, error) {
+	assigned := make(map[int]interface{})
+	for key, v := range values {
+		i := resolve(len(prompts), key, func(i int) (string, string, string) {
+			return prompts[i].PromptID, prompts[i].Text, ""
+		})
+		if i < 0 {
+			return nil, fmt.Errorf("%w: Prompt: %v", ErrNotFound, key)
+		}
+		assigned[i] = v
+	}
+	res := []PromptValue{}
+	for i, p := range prompts {
+		v, ok := assigned[i]
+		if !ok {
+			if p.Default == "" && p.Required {
+				return nil, fmt.Errorf("%w: Prompt without Value: %v", ErrValidation, p.Text)
+			}
+			v = p.Default
+		}
+		res = append(res, PromptValue{
+			BusObID:                  p.BusObID,
+			CollectionStoreEntireRow: p.CollectionStoreEntireRow,
+			CollectionValueField:     p.CollectionValueField,
+			FieldID:                  p.FieldID,
+			ListReturnFieldID:        p.ListReturnFieldID,
+			PromptID:                 p.PromptID,
+			Value:                    formatValue(nil, v),
+		})
+	}
+	return res, nil
+}
@@ -0,0 +1,201 @@
+package gocherwell_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/itsscb/gocherwell"
+	"github.com/itsscb/gocherwell/cherwelltest"
+)
+
+// newSearchServer returns a Server with the BusinessObject of testComputer and stored Searches
+// in the Scopes Global, Team and User. The Search By Status has a required Prompt.
+func newSearchServer() *cherwelltest.Server {
+	def := testComputer()
+	def.Searches = []cherwelltest.Search{
+		{SearchID: "SEActive", Name: "Active Computers", Filters: []cherwelltest.Filter{{Field: "Status", Operator: "eq", Value: "Active"}}},
+		{SearchID: "SEAll", Name: "Computers", Filters: []cherwelltest.Filter{{Field: "AssetName", Operator: "startswith", Value: "NB"}}},
+		{SearchID: "SEMine", Name: "Computers", Scope: "User", ScopeOwner: "cherwelltest",
+			Filters: []cherwelltest.Filter{{Field: "Status", Operator: "eq", Value: "Retired"}}},
+		{SearchID: "SEByType", Name: "By Type", Filters: []cherwelltest.Filter{{Field: "AssetType", Operator: "eq", Value: "Phone", Prompt: "Type"}}},
+		{SearchID: "SEByStatus", Name: "By Status", Scope: "Team", ScopeOwner: "IT", Filters: []cherwelltest.Filter{
+			{Field: "Status", Operator: "eq", Prompt: "Status"},
+			{Field: "AssetType", Operator: "eq", Value: "Desktop", Prompt: "Type"},
+		}},
+	}
+	return cherwelltest.NewServer(def)
+}
+
+func TestGetSearchItems(t *testing.T) {
+	srv := newSearchServer()
+	defer srv.Close()
+	ctx := context.Background()
+	cl := srv.NewClient()
+	bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	all, err := cl.GetSearchItems(ctx)
+	if err != nil {
+		t.Fatalf("Client.GetSearchItems() error = %v", err)
+	}
+	root, err := bo.GetSearchItems(ctx, cl)
+	if err != nil {
+		t.Fatalf("BusinessObject.GetSearchItems() error = %v", err)
+	}
+	if got, want := len(all.Items()), len(root.Items()); got != 5 || want != 5 {
+		t.Errorf("Items() = %v and %v, want 5", got, want)
+	}
+
+	tests := []struct {
+		scope, owner string
+		ids          []string
+	}{
+		{"Global", "", []string{"SEActive", "SEAll", "SEByType"}},
+		{"User", "cherwelltest", []string{"SEMine"}},
+		{"Team", "IT", []string{"SEByStatus"}},
+	}
+	if len(root.ChildFolders) != len(tests) {
+		t.Fatalf("ChildFolders = %+v, want one per Scope", root.ChildFolders)
+	}
+	for i, tt := range tests {
+		t.Run(tt.scope, func(t *testing.T) {
+			f := root.ChildFolders[i]
+			ids := []string{}
+			for _, item := range f.Items() {
+				ids = append(ids, item.ID)
+				if item.Scope != tt.scope || item.Association != "BO1" {
+					t.Errorf("SearchItem %v has Scope %v and Association %v, want %v and BO1", item.ID, item.Scope, item.Association, tt.scope)
+				}
+			}
+			if f.Scope != tt.scope || f.ScopeOwner != tt.owner || strings.Join(ids, ",") != strings.Join(tt.ids, ",") {
+				t.Errorf("ChildFolders[%v] = %v (%v) with %v, want %v (%v) with %v", i, f.Scope, f.ScopeOwner, ids, tt.scope, tt.owner, tt.ids)
+			}
+		})
+	}
+}
+
+func TestGetStoredSearch(t *testing.T) {
+	tests := []struct {
+		name  string
+		id    string
+		scope string
+		want  string
+		err   error
+	}{
+		{"by name", "Active Computers", "", "SEActive", nil},
+		{"by id", "SEByType", "", "SEByType", nil},
+		{"name in global scope", "Computers", "Global", "SEAll", nil},
+		{"name in user scope", "Computers", "user", "SEMine", nil},
+		{"name ignoring case", "by status", "Team", "SEByStatus", nil},
+		{"other scope", "By Status", "Global", "", gocherwell.ErrNotFound},
+		{"missing", "Laptops", "", "", gocherwell.ErrNotFound},
+	}
+	srv := newSearchServer()
+	defer srv.Close()
+	ctx := context.Background()
+	cl := srv.NewClient()
+	bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := bo.GetStoredSearch(ctx, cl, tt.id, tt.scope)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("GetStoredSearch() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && s.ID != tt.want {
+				t.Errorf("GetStoredSearch() = %v, want %v", s.ID, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchItemRecords(t *testing.T) {
+	tests := []struct {
+		name   string
+		search string
+		scope  string
+		values map[string]interface{}
+		count  int
+		first  string
+		err    error
+	}{
+		{"without prompts", "Active Computers", "", nil, 20, "NB001", nil},
+		{"scope", "Computers", "User", nil, 10, "NB000", nil},
+		{"prompt default", "By Type", "", nil, 10, "NB002", nil},
+		{"prompt by text", "By Type", "", map[string]interface{}{"type": "Desktop"}, 10, "NB001", nil},
+		{"required prompt and default", "By Status", "", map[string]interface{}{"Status": "Active"}, 10, "NB001", nil},
+		{"all prompts", "By Status", "", map[string]interface{}{"Status": "Active", "Type": "Phone"}, 10, "NB002", nil},
+		{"prompt by id", "By Status", "", map[string]interface{}{"PRSEByStatus0": "Retired", "PRSEByStatus1": "Notebook"}, 10, "NB000", nil},
+		{"no match", "By Status", "", map[string]interface{}{"Status": "Retired"}, 0, "", nil},
+		{"missing required prompt", "By Status", "", nil, 0, "", gocherwell.ErrValidation},
+		{"unknown prompt", "By Type", "", map[string]interface{}{"Owner": "IT"}, 0, "", gocherwell.ErrNotFound},
+	}
+	srv := newSearchServer()
+	defer srv.Close()
+	ctx := context.Background()
+	cl := srv.NewClient()
+	bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := bo.GetStoredSearch(ctx, cl, tt.search, tt.scope)
+			if err != nil {
+				t.Fatal(err)
+			}
+			before := countRequests(srv, "getsearchresults")
+			recs, err := s.Records(ctx, cl, tt.values)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Records() error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				if got := countRequests(srv, "getsearchresults") - before; got != 1 {
+					t.Errorf("Records() sent %v Searches, want only the one for the Prompts", got)
+				}
+				return
+			}
+			names := assetNames(recs)
+			if len(names) != tt.count || (tt.count > 0 && names[0] != tt.first) {
+				t.Errorf("Records() = %v, want %v starting with %v", names, tt.count, tt.first)
+			}
+		})
+	}
+}
+
+func TestSearchItemIteratorIsLazy(t *testing.T) {
+	srv := newSearchServer()
+	defer srv.Close()
+	ctx := context.Background()
+	cl := srv.NewClient()
+	bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := bo.GetStoredSearch(ctx, cl, "By Type", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	it := s.Iterator(ctx, cl, nil)
+	defer it.Close()
+	if got := countRequests(srv, "getsearchresults"); got != 0 {
+		t.Errorf("Iterator() sent %v Searches, want 0 before Next", got)
+	}
+	if !it.Next() {
+		t.Fatalf("Next() = false, error = %v", it.Err())
+	}
+	if got := countRequests(srv, "getsearchresults"); got != 2 {
+		t.Errorf("Next() sent %v Searches, want 2 for the Prompts and the first Page", got)
+	}
+
+	var nilSearch *gocherwell.SearchItem
+	it = nilSearch.Iterator(ctx, cl, nil)
+	if it.Next() || !errors.Is(it.Err(), gocherwell.ErrNilReceiver) {
+		t.Errorf("Iterator() of nil SearchItem error = %v, want ErrNilReceiver", it.Err())
+	}
+}
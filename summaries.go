@@ -0,0 +1,71 @@
+package gocherwell
+
+import (
+	"context"
+	"fmt"
+)
+
+// BusinessObjectType selects the BusinessObjects listed by GetBusinessObjectsByType.
+type BusinessObjectType string
+
+// Types of BusinessObjects known to Cherwell.
+const (
+	BusinessObjectTypeAll        BusinessObjectType = "All"
+	BusinessObjectTypeMajor      BusinessObjectType = "Major"
+	BusinessObjectTypeSupporting BusinessObjectType = "Supporting"
+	BusinessObjectTypeLookup     BusinessObjectType = "Lookup"
+	BusinessObjectTypeGroups     BusinessObjectType = "Groups"
+)
+
+// GetBusinessObjectByName retreives a Cherwell BusinessObject by given internal Name and returns it
+func (cl *Client) GetBusinessObjectByName(ctx context.Context, name string) (*BusinessObject, error) {
+	val := make(map[string]string)
+	val["busobname"] = name
+	return cl.getBusinessObjectSummary(ctx, getBusObSummaryByNameURI, val, summaryKey+"name:"+name, name)
+}
+
+// GetBusinessObjectsByType retreives the Cherwell BusinessObjects of the given Type and returns them.
+// Members of Groups are returned as GroupSummaries of their Group.
+func (cl *Client) GetBusinessObjectsByType(ctx context.Context, typ BusinessObjectType) (*[]BusinessObject, error) {
+	res, err := cl.getBusinessObjectSummaries(ctx, typ)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// getBusinessObjectSummaries retreives the Summaries of all BusinessObjects of the given Type
+func (cl *Client) getBusinessObjectSummaries(ctx context.Context, typ BusinessObjectType) ([]BusinessObject, error) {
+	res := []BusinessObject{}
+	val := make(map[string]string)
+	val["busobtype"] = string(typ)
+	err := cl.cached(summariesKey+string(typ), "", &res, func() error {
+		return cl.request(ctx, "GET", getBusObSummariesURI, val, nil, &res)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// getBusinessObjectSummary retreives the Summary of a single BusinessObject from the given Endpoint.
+// A BusinessObject that is not found is not cached, so it is found once it has been created
+func (cl *Client) getBusinessObjectSummary(ctx context.Context, endpoint string, val map[string]string, key, id string) (*BusinessObject, error) {
+	res := []BusinessObject{}
+	err := cl.cached(key, "", &res, func() error {
+		if err := cl.request(ctx, "GET", endpoint, val, nil, &res); err != nil {
+			return err
+		}
+		if len(res) == 0 {
+			return fmt.Errorf("%w: BusinessObject: %v", ErrNotFound, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%w: BusinessObject: %v", ErrNotFound, id)
+	}
+	return &res[0], nil
+}
@@ -0,0 +1,152 @@
+package gocherwell_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/itsscb/gocherwell"
+	"github.com/itsscb/gocherwell/cherwelltest"
+)
+
+// newSummaryServer returns a Server with BusinessObjects of every Type: the Group ConfigurationItem
+// with its Members ConfigComputer and ConfigPrinter, the Major Incident, the Supporting Location and the Lookup Priority.
+func newSummaryServer() *cherwelltest.Server {
+	return cherwelltest.NewServer(
+		cherwelltest.BusinessObject{Name: "ConfigurationItem", DisplayName: "Configuration Item", Type: "Group"},
+		cherwelltest.BusinessObject{Name: "ConfigComputer", DisplayName: "Computer", Group: "BOConfigurationItem"},
+		cherwelltest.BusinessObject{Name: "ConfigPrinter", DisplayName: "Drucker", Group: "BOConfigurationItem"},
+		cherwelltest.BusinessObject{Name: "Incident"},
+		cherwelltest.BusinessObject{Name: "Location", DisplayName: "Standort", Type: "Supporting"},
+		cherwelltest.BusinessObject{Name: "Priority", Type: "Lookup"},
+	)
+}
+
+func TestGetBusinessObjectByName(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		busObID string
+		err     error
+	}{
+		{"Incident", "Incident", "BOIncident", nil},
+		{"incident", "Incident", "BOIncident", nil},
+		{"ConfigPrinter", "ConfigPrinter", "BOConfigPrinter", nil},
+		{"Standort", "", "", gocherwell.ErrNotFound},
+		{"Missing", "", "", gocherwell.ErrNotFound},
+	}
+	srv := newSummaryServer()
+	defer srv.Close()
+	cl := srv.NewClient()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bo, err := cl.GetBusinessObjectByName(context.Background(), tt.name)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("GetBusinessObjectByName() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && (bo.Name != tt.want || bo.BusObID != tt.busObID) {
+				t.Errorf("GetBusinessObjectByName() = %v (%v), want %v (%v)", bo.Name, bo.BusObID, tt.want, tt.busObID)
+			}
+		})
+	}
+	if got := countRequests(srv, "getbusinessobjectsummaries"); got != 0 {
+		t.Errorf("Summaries of all BusinessObjects requested %v times, want 0", got)
+	}
+}
+
+func TestGetBusinessObjectByBusObID(t *testing.T) {
+	srv := newSummaryServer()
+	defer srv.Close()
+	cl := srv.NewClient()
+	ctx := context.Background()
+	if bo, err := cl.GetBusinessObjectByBusObID(ctx, "BOLocation"); err != nil || bo.DisplayName != "Standort" || !bo.Supporting {
+		t.Errorf("GetBusinessObjectByBusObID() = %+v, %v, want the Supporting Location", bo, err)
+	}
+	if _, err := cl.GetBusinessObjectByBusObID(ctx, "Location"); !errors.Is(err, gocherwell.ErrNotFound) {
+		t.Errorf("GetBusinessObjectByBusObID() by Name error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestGetBusinessObjectsByType(t *testing.T) {
+	tests := []struct {
+		typ  gocherwell.BusinessObjectType
+		want []string
+	}{
+		{gocherwell.BusinessObjectTypeAll, []string{"ConfigurationItem(ConfigComputer,ConfigPrinter)", "Incident", "Location", "Priority"}},
+		{gocherwell.BusinessObjectTypeMajor, []string{"ConfigurationItem(ConfigComputer,ConfigPrinter)", "Incident"}},
+		{gocherwell.BusinessObjectTypeGroups, []string{"ConfigurationItem(ConfigComputer,ConfigPrinter)"}},
+		{gocherwell.BusinessObjectTypeSupporting, []string{"Location"}},
+		{gocherwell.BusinessObjectTypeLookup, []string{"Priority"}},
+	}
+	srv := newSummaryServer()
+	defer srv.Close()
+	cl := srv.NewClient()
+	for _, tt := range tests {
+		t.Run(string(tt.typ), func(t *testing.T) {
+			res, err := cl.GetBusinessObjectsByType(context.Background(), tt.typ)
+			if err != nil {
+				t.Fatalf("GetBusinessObjectsByType() error = %v", err)
+			}
+			names := []string{}
+			for _, bo := range *res {
+				name := bo.Name
+				if len(bo.GroupSummaries) > 0 {
+					members := []string{}
+					for _, m := range bo.GroupSummaries {
+						members = append(members, m.Name)
+					}
+					name += "(" + strings.Join(members, ",") + ")"
+				}
+				names = append(names, name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("GetBusinessObjectsByType() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveBusinessObjectByName(t *testing.T) {
+	tests := []struct {
+		id        string
+		want      string
+		summaries int
+		err       error
+	}{
+		{"Location", "Location", 0, nil},
+		{"configprinter", "ConfigPrinter", 0, nil},
+		{"BOLocation", "Location", 1, nil},
+		{"Standort", "Location", 1, nil},
+		{"Drucker", "ConfigPrinter", 1, nil},
+		{"Missing", "", 1, gocherwell.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			srv := newSummaryServer()
+			defer srv.Close()
+			cl := srv.NewClient()
+			bo, err := cl.ResolveBusinessObject(context.Background(), tt.id)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ResolveBusinessObject() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && bo.Name != tt.want {
+				t.Errorf("ResolveBusinessObject() = %v, want %v", bo.Name, tt.want)
+			}
+			if got := countRequests(srv, "getbusinessobjectsummary/busobname"); got != 1 {
+				t.Errorf("busobname requested %v times, want 1", got)
+			}
+			if got := countRequests(srv, "getbusinessobjectsummaries"); got != tt.summaries {
+				t.Errorf("Summaries of all BusinessObjects requested %v times, want %v", got, tt.summaries)
+			}
+		})
+	}
+
+	srv := newSummaryServer()
+	defer srv.Close()
+	cl := srv.NewClient(gocherwell.WithRetryPolicy(gocherwell.RetryPolicy{MaxAttempts: 1}))
+	srv.FailNext("busobname", 500, nil)
+	if _, err := cl.ResolveBusinessObject(context.Background(), "Location"); err == nil {
+		t.Errorf("ResolveBusinessObject() error = nil, want the Error of the Server")
+	}
+}
@@ -0,0 +1,227 @@
+package gocherwell
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// tokenExpiryMargin is the Duration before the Expiry of an AccessToken at which it is refreshed.
+const tokenExpiryMargin = 5 * time.Minute
+
+// Token contains the Values of an AccessToken as returned by the token endpoint of the Cherwell Server.
+type Token struct {
+	AccessToken  string     `json:"access_token,omitempty"`
+	TokenType    string     `json:"token_type,omitempty"`
+	RefreshToken string     `json:"refresh_token,omitempty"`
+	ExpiresIn    int        `json:"expires_in,omitempty"`
+	Issued       string     `json:".issued,omitempty"`
+	Expires      string     `json:".expires,omitempty"`
+	Expiry       *time.Time `json:"expiry,omitempty"`
+}
+
+// setExpiry computes the Expiry of the Token from the .expires Header and falls back
+// to expires_in relative to the given Time if the Header cannot be parsed. A zero Expiry, e.g. from an
+// old Cache, is treated as missing
+func (t *Token) setExpiry(now time.Time) {
+	if t.Expiry != nil && !t.Expiry.IsZero() {
+		return
+	}
+	t.Expiry = nil
+	for _, layout := range []string{time.RFC1123, time.RFC1123Z, time.RFC3339} {
+		if e, err := time.Parse(layout, t.Expires); err == nil {
+			t.Expiry = &e
+			return
+		}
+	}
+	if t.ExpiresIn > 0 {
+		e := now.Add(time.Duration(t.ExpiresIn) * time.Second)
+		t.Expiry = &e
+	}
+}
+
+// Valid reports whether the Token has an AccessToken which does not expire within the next five Minutes.
+// A Token without Expiry is considered valid.
+func (t *Token) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	if t.Expiry == nil || t.Expiry.IsZero() {
+		return true
+	}
+	return time.Now().Before(t.Expiry.Add(-tokenExpiryMargin))
+}
+
+// copy returns a deep copy of the Token, which does not share its Expiry
+func (t *Token) copy() *Token {
+	c := *t
+	if t.Expiry != nil {
+		e := *t.Expiry
+		c.Expiry = &e
+	}
+	return &c
+}
+
+// tokenManager guards the Token of a Client and deduplicates concurrent refreshes.
+type tokenManager struct {
+	mu       sync.Mutex
+	token    *Token
+	inflight *tokenCall
+	closed   bool
+}
+
+// tokenCall is a refresh in progress which other goroutines wait for.
+type tokenCall struct {
+	done  chan struct{}
+	token *Token
+	err   error
+}
+
+// set stores a copy of the given Token
+func (tm *tokenManager) set(t *Token) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if t == nil {
+		tm.token = nil
+		return
+	}
+	c := t.copy()
+	c.setExpiry(time.Now())
+	tm.token = c
+}
+
+// isClosed reports whether the Client was closed by Logout
+func (tm *tokenManager) isClosed() bool {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	return tm.closed
+}
+
+// get returns a copy of the current Token or nil
+func (tm *tokenManager) get() *Token {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if tm.token == nil {
+		return nil
+	}
+	return tm.token.copy()
+}
+
+// invalidate marks the given AccessToken as expired, if it is still the current one,
+// so the next call to Client.accessToken refreshes it
+func (tm *tokenManager) invalidate(accessToken string) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if tm.token != nil && tm.token.AccessToken == accessToken {
+		expired := time.Unix(0, 0)
+		tm.token.Expiry = &expired
+	}
+}
+
+// close clears the Token and marks the Client as closed. It returns the cleared Token
+// and whether the Client was already closed.
+func (tm *tokenManager) close() (*Token, bool) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if tm.closed {
+		return nil, true
+	}
+	t := tm.token
+	tm.token = nil
+	tm.closed = true
+	return t, false
+}
+
+// Token returns a copy of the current Token of the Client or nil if the Client is not logged in.
+func (cl *Client) Token() *Token {
+	return cl.tokens.get()
+}
+
+// accessToken returns a valid AccessToken. If the current one is expired, exactly one goroutine
+// refreshes it while all others wait for the result.
+func (cl *Client) accessToken(ctx context.Context) (string, error) {
+	for {
+		tm := &cl.tokens
+		tm.mu.Lock()
+		if tm.closed {
+			tm.mu.Unlock()
+			return "", ErrClientClosed
+		}
+		if tm.token.Valid() {
+			t := tm.token.AccessToken
+			tm.mu.Unlock()
+			return t, nil
+		}
+		call := tm.inflight
+		if call == nil {
+			call = &tokenCall{done: make(chan struct{})}
+			tm.inflight = call
+			var refreshToken string
+			if tm.token != nil {
+				refreshToken = tm.token.RefreshToken
+			}
+			tm.mu.Unlock()
+
+			call.token, call.err = cl.renewToken(ctx, refreshToken)
+
+			tm.mu.Lock()
+			if call.err == nil && !tm.closed {
+				tm.token = call.token
+			}
+			tm.inflight = nil
+			tm.mu.Unlock()
+			close(call.done)
+		} else {
+			tm.mu.Unlock()
+			select {
+			case <-call.done:
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		}
+
+		if call.err == nil {
+			return call.token.AccessToken, nil
+		}
+		// The refresh was cancelled by the Context of another goroutine, so try again with our own.
+		if isContextError(call.err) && ctx.Err() == nil {
+			continue
+		}
+		return "", call.err
+	}
+}
+
+// renewToken retreives a new Token with the RefreshToken and falls back to the TokenSource exactly once
+func (cl *Client) renewToken(ctx context.Context, refreshToken string) (*Token, error) {
+	var t *Token
+	var err error
+	if refreshToken != "" {
+		t, err = cl.keepAlive(ctx, refreshToken)
+		if err == nil {
+			t.setExpiry(time.Now())
+			if store, ok := cl.tokenSource().(TokenStore); ok {
+				// The refreshed Token is valid even if it could not be cached.
+				if err := store.SaveToken(t); err != nil {
+					cl.log().Warn("failed to save refreshed token", "error", err)
+				}
+			}
+			return t, nil
+		}
+		if isContextError(err) {
+			return nil, err
+		}
+		cl.log().Info("token refresh failed, logging in again", "error", err)
+	}
+	t, err = cl.tokenSource().Token(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+	t.setExpiry(time.Now())
+	return t, nil
+}
+
+// isContextError reports whether the given error was caused by a cancelled or expired Context
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
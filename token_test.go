@@ -0,0 +1,127 @@
+package gocherwell_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itsscb/gocherwell"
+	"github.com/itsscb/gocherwell/cherwelltest"
+)
+
+func TestClientReauthenticates(t *testing.T) {
+	tests := []struct {
+		name   string
+		setup  func(srv *cherwelltest.Server)
+		grants []string
+	}{
+		{"valid token is reused", func(srv *cherwelltest.Server) {}, []string{"password"}},
+		{"expired token is refreshed", func(srv *cherwelltest.Server) {
+			srv.ExpireTokens()
+		}, []string{"password", "refresh_token"}},
+		{"failed refresh logs in again", func(srv *cherwelltest.Server) {
+			srv.ExpireTokens()
+			srv.FailNext("token", http.StatusBadRequest, nil)
+		}, []string{"password", "refresh_token", "password"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer()
+			defer srv.Close()
+			ctx := context.Background()
+			cl := srv.NewClient()
+			bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.setup(srv)
+			n, err := bo.NewQuery().Where("Status").Eq("Active").Count(ctx, cl)
+			if err != nil {
+				t.Fatalf("Count() error = %v", err)
+			}
+			if n != 20 {
+				t.Errorf("Count() = %v, want 20", n)
+			}
+			if got := grantTypes(srv); strings.Join(got, ",") != strings.Join(tt.grants, ",") {
+				t.Errorf("grant types = %v, want %v", got, tt.grants)
+			}
+		})
+	}
+}
+
+func TestClientConcurrentRefresh(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	ctx := context.Background()
+	cl := srv.NewClient()
+	bo, err := cl.ResolveBusinessObject(ctx, "Computer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.ExpireTokens()
+
+	errs := make(chan error, 10)
+	for i := 0; i < cap(errs); i++ {
+		go func() {
+			_, err := bo.NewQuery().Where("Status").Eq("Retired").Count(ctx, cl)
+			errs <- err
+		}()
+	}
+	for i := 0; i < cap(errs); i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("Count() error = %v", err)
+		}
+	}
+	if got := grantTypes(srv); len(got) != 2 {
+		t.Errorf("grant types = %v, want one password and one refresh_token", got)
+	}
+}
+
+func TestTokenExpiryJSON(t *testing.T) {
+	expiry := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name  string
+		token gocherwell.Token
+		want  string
+	}{
+		{"without expiry", gocherwell.Token{AccessToken: "a"}, `{"access_token":"a"}`},
+		{"with expiry", gocherwell.Token{AccessToken: "a", Expiry: &expiry}, `{"access_token":"a","expiry":"2026-01-02T03:04:05Z"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("json.Marshal() = %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenValid(t *testing.T) {
+	soon := time.Now().Add(time.Minute)
+	later := time.Now().Add(time.Hour)
+	tests := []struct {
+		name  string
+		token *gocherwell.Token
+		want  bool
+	}{
+		{"nil", nil, false},
+		{"without access token", &gocherwell.Token{Expiry: &later}, false},
+		{"without expiry", &gocherwell.Token{AccessToken: "a"}, true},
+		{"expires soon", &gocherwell.Token{AccessToken: "a", Expiry: &soon}, false},
+		{"expires later", &gocherwell.Token{AccessToken: "a", Expiry: &later}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.token.Valid(); got != tt.want {
+				t.Errorf("Valid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package gocherwell
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/itsscb/gocherwell/internal/atomicfile"
+)
+
+// Environment Variables read by EnvTokenSource.
+const (
+	EnvAccessToken  = "CHERWELL_ACCESS_TOKEN"
+	EnvRefreshToken = "CHERWELL_REFRESH_TOKEN"
+	EnvTokenExpiry  = "CHERWELL_TOKEN_EXPIRY"
+)
+
+// errNoTokenKey is returned by FileTokenSource if no Key is configured.
+var errNoTokenKey = errors.New("token cache needs a Key for encryption")
+
+// TokenSource provides the Tokens of a Client. It is called by Login and whenever
+// the Token cannot be refreshed with its RefreshToken.
+// The Client is passed to give access to its configuration and HTTP-Transport.
+type TokenSource interface {
+	Token(ctx context.Context, cl *Client) (*Token, error)
+}
+
+// TokenStore is implemented by TokenSources which persist Tokens, like FileTokenSource.
+// The Client saves every Token it obtains by a refresh in it.
+type TokenStore interface {
+	SaveToken(t *Token) error
+}
+
+// TokenSourceFunc is an adapter to use an ordinary function as TokenSource.
+type TokenSourceFunc func(ctx context.Context, cl *Client) (*Token, error)
+
+// Token calls f(ctx, cl).
+func (f TokenSourceFunc) Token(ctx context.Context, cl *Client) (*Token, error) {
+	return f(ctx, cl)
+}
+
+// WithTokenSource sets the TokenSource of the Client. Default is PasswordGrant.
+func WithTokenSource(ts TokenSource) Option {
+	return func(o *options) {
+		o.tokenSource = ts
+	}
+}
+
+// PasswordGrant authenticates with User, Password, Grant_Type and Auth_mode of the Client.
+type PasswordGrant struct{}
+
+// Token implements TokenSource.
+func (PasswordGrant) Token(ctx context.Context, cl *Client) (*Token, error) {
+	return cl.passwordGrant(ctx)
+}
+
+// RefreshGrant retreives a new Token with a RefreshToken obtained elsewhere, e.g. by another process.
+type RefreshGrant struct {
+	RefreshToken string
+}
+
+// Token implements TokenSource.
+func (g RefreshGrant) Token(ctx context.Context, cl *Client) (*Token, error) {
+	if g.RefreshToken == "" {
+		return nil, fmt.Errorf("%w: no RefreshToken available", ErrUnauthorized)
+	}
+	return cl.keepAlive(ctx, g.RefreshToken)
+}
+
+// StaticTokenSource returns a TokenSource which always returns a copy of the given Token.
+func StaticTokenSource(t *Token) TokenSource {
+	return TokenSourceFunc(func(ctx context.Context, cl *Client) (*Token, error) {
+		if t == nil || t.AccessToken == "" {
+			return nil, fmt.Errorf("%w: no AccessToken available", ErrUnauthorized)
+		}
+		return t.copy(), nil
+	})
+}
+
+// EnvTokenSource reads the Token from the Environment Variables CHERWELL_ACCESS_TOKEN,
+// CHERWELL_REFRESH_TOKEN and CHERWELL_TOKEN_EXPIRY (RFC3339 or RFC1123).
+// If only a RefreshToken is set, a new Token is retreived with it.
+type EnvTokenSource struct{}
+
+// Token implements TokenSource.
+func (EnvTokenSource) Token(ctx context.Context, cl *Client) (*Token, error) {
+	t := &Token{
+		AccessToken:  os.Getenv(EnvAccessToken),
+		RefreshToken: os.Getenv(EnvRefreshToken),
+		TokenType:    "bearer",
+		Expires:      os.Getenv(EnvTokenExpiry),
+	}
+	t.setExpiry(time.Now())
+	if t.AccessToken != "" && t.Valid() {
+		return t, nil
+	}
+	if t.RefreshToken != "" {
+		return RefreshGrant{RefreshToken: t.RefreshToken}.Token(ctx, cl)
+	}
+	return nil, fmt.Errorf("%w: %v is not set or expired", ErrUnauthorized, EnvAccessToken)
+}
+
+// FileTokenSource caches the Token in a File encrypted with AES-GCM, so it can be reused
+// across invocations of a CLI. If the cached Token is expired it is refreshed with its
+// RefreshToken, otherwise a new Token is retreived from Source (default PasswordGrant).
+type FileTokenSource struct {
+	Path   string
+	Key    []byte
+	Source TokenSource
+
+	mu sync.Mutex
+}
+
+// NewFileTokenSource returns a Pointer to a FileTokenSource storing the Token at the given Path
+// encrypted with the given Key.
+func NewFileTokenSource(path string, key []byte, src TokenSource) *FileTokenSource {
+	return &FileTokenSource{
+		Path:   path,
+		Key:    key,
+		Source: src,
+	}
+}
+
+// Token implements TokenSource.
+func (fs *FileTokenSource) Token(ctx context.Context, cl *Client) (*Token, error) {
+	if len(fs.Key) == 0 {
+		return nil, errNoTokenKey
+	}
+	// A missing or undecryptable cache is not an error, the Token is just retreived again.
+	if t, err := fs.load(); err == nil {
+		if t.Valid() {
+			return t, nil
+		}
+		if t.RefreshToken != "" {
+			t, err := cl.keepAlive(ctx, t.RefreshToken)
+			if err == nil {
+				fs.save(cl, t)
+				return t, nil
+			}
+			if isContextError(err) {
+				return nil, err
+			}
+		}
+	}
+
+	src := fs.Source
+	if src == nil {
+		src = PasswordGrant{}
+	}
+	t, err := src.Token(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+	fs.save(cl, t)
+	return t, nil
+}
+
+// save caches the Token and logs a failure, as the Token is valid even if it could not be cached
+func (fs *FileTokenSource) save(cl *Client, t *Token) {
+	if err := fs.SaveToken(t); err != nil {
+		cl.log().Warn("failed to save token", "path", fs.Path, "error", err)
+	}
+}
+
+// SaveToken implements TokenStore and writes the encrypted Token to the File.
+func (fs *FileTokenSource) SaveToken(t *Token) error {
+	c := t.copy()
+	c.setExpiry(time.Now())
+	plain, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	gcm, err := fs.cipher()
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	data := gcm.Seal(nonce, nonce, plain, nil)
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(fs.Path), 0700); err != nil {
+		return err
+	}
+	return atomicfile.WriteFile(fs.Path, data, 0600)
+}
+
+// Clear removes the cached Token.
+func (fs *FileTokenSource) Clear() error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	err := os.Remove(fs.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// load reads and decrypts the cached Token
+func (fs *FileTokenSource) load() (*Token, error) {
+	fs.mu.Lock()
+	data, err := os.ReadFile(fs.Path)
+	fs.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := fs.cipher()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("token cache %v is corrupt", fs.Path)
+	}
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("token cache %v cannot be decrypted: %w", fs.Path, err)
+	}
+	t := Token{}
+	if err := json.Unmarshal(plain, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// cipher derives the AES-256-GCM cipher from the Key
+func (fs *FileTokenSource) cipher() (cipher.AEAD, error) {
+	if len(fs.Key) == 0 {
+		return nil, errNoTokenKey
+	}
+	key := sha256.Sum256(fs.Key)
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,136 @@
+package gocherwell_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itsscb/gocherwell"
+	"github.com/itsscb/gocherwell/cherwelltest"
+)
+
+func TestFileTokenSource(t *testing.T) {
+	tests := []struct {
+		name string
+		// prepare writes the cached Token and returns the Path of the FileTokenSource
+		prepare func(t *testing.T, srv *cherwelltest.Server, dir string) string
+		grants  []string
+		log     string
+	}{
+		{"missing cache logs in", func(t *testing.T, srv *cherwelltest.Server, dir string) string {
+			return filepath.Join(dir, "token")
+		}, []string{"password"}, ""},
+		{"valid cache is reused", func(t *testing.T, srv *cherwelltest.Server, dir string) string {
+			path := filepath.Join(dir, "token")
+			login(t, srv, gocherwell.NewFileTokenSource(path, []byte("key"), nil))
+			return path
+		}, []string{"password"}, ""},
+		{"expired cache is refreshed", func(t *testing.T, srv *cherwelltest.Server, dir string) string {
+			path := filepath.Join(dir, "token")
+			fs := gocherwell.NewFileTokenSource(path, []byte("key"), nil)
+			tok := login(t, srv, fs)
+			expired := time.Now().Add(-time.Hour)
+			tok.Expiry = &expired
+			if err := fs.SaveToken(tok); err != nil {
+				t.Fatal(err)
+			}
+			return path
+		}, []string{"password", "refresh_token"}, ""},
+		{"cache of other key is ignored", func(t *testing.T, srv *cherwelltest.Server, dir string) string {
+			path := filepath.Join(dir, "token")
+			login(t, srv, gocherwell.NewFileTokenSource(path, []byte("other"), nil))
+			return path
+		}, []string{"password", "password"}, ""},
+		{"failed save is logged", func(t *testing.T, srv *cherwelltest.Server, dir string) string {
+			file := filepath.Join(dir, "file")
+			if err := ioutil.WriteFile(file, nil, 0600); err != nil {
+				t.Fatal(err)
+			}
+			return filepath.Join(file, "token")
+		}, []string{"password"}, `msg="failed to save token"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer()
+			defer srv.Close()
+			dir, err := ioutil.TempDir("", "gocherwell")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			path := tt.prepare(t, srv, dir)
+
+			buf := &bytes.Buffer{}
+			cl := srv.NewClient(
+				gocherwell.WithTokenSource(gocherwell.NewFileTokenSource(path, []byte("key"), nil)),
+				gocherwell.WithLogger(gocherwell.NewWriterLogger(buf, gocherwell.LevelWarn)),
+			)
+			if _, err := cl.ResolveBusinessObject(context.Background(), "Computer"); err != nil {
+				t.Fatalf("ResolveBusinessObject() error = %v", err)
+			}
+			if got := grantTypes(srv); strings.Join(got, ",") != strings.Join(tt.grants, ",") {
+				t.Errorf("grant types = %v, want %v", got, tt.grants)
+			}
+			if tt.log == "" && buf.Len() > 0 || !strings.Contains(buf.String(), tt.log) {
+				t.Errorf("log = %q, want %q", buf.String(), tt.log)
+			}
+		})
+	}
+}
+
+func TestFileTokenSourceLogoutClearsCache(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	dir, err := ioutil.TempDir("", "gocherwell")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "token")
+
+	cl := srv.NewClient(gocherwell.WithTokenSource(gocherwell.NewFileTokenSource(path, []byte("key"), nil)))
+	if _, err := cl.Login(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("cached Token missing: %v", err)
+	}
+	if err := cl.Logout(context.Background()); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("cached Token after Logout: %v, want removed", err)
+	}
+}
+
+// login logs in with the given TokenSource and returns the Token
+func TestStaticTokenSourceCopiesExpiry(t *testing.T) {
+	expiry := time.Now().Add(time.Hour)
+	ts := gocherwell.StaticTokenSource(&gocherwell.Token{AccessToken: "access", Expiry: &expiry})
+	tok, err := ts.Token(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	*tok.Expiry = time.Time{}
+	again, err := ts.Token(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !again.Expiry.Equal(expiry) {
+		t.Errorf("Expiry = %v after changing a returned Token, want %v", again.Expiry, expiry)
+	}
+}
+
+func login(t *testing.T, srv *cherwelltest.Server, ts gocherwell.TokenSource) *gocherwell.Token {
+	t.Helper()
+	cl, err := srv.NewClient(gocherwell.WithTokenSource(ts)).Login(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cl.Token()
+}
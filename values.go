@@ -0,0 +1,324 @@
+package gocherwell
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// RecordValues reads and writes the FieldValues of a BusinessObjectRecord as typed Values.
+// They are parsed and formatted in the Wire format of Cherwell by the FieldDefinitions of the BusinessObjectSchema.
+type RecordValues struct {
+	rec *BusinessObjectRecord
+	sch *BusinessObjectSchema
+}
+
+// Values returns the FieldValues of the BusinessObjectRecord as RecordValues, typed by the FieldDefinitions
+// of the given BusinessObjectSchema. Without BusinessObjectSchema the Values are not checked against the
+// Type of their Field and Times are formatted with Date and Time.
+//
+//	sch, err := bo.GetBusinessObjectSchema(ctx, cl)
+//	cost, err := rec.Values(sch).Decimal("Cost")
+//	err = rec.Values(sch).SetDecimal("Cost", cost*1.1)
+//	resp, err := rec.SaveBusinessObjectRecord(ctx, cl)
+func (rec *BusinessObjectRecord) Values(sch *BusinessObjectSchema) *RecordValues {
+	return &RecordValues{rec: rec, sch: sch}
+}
+
+// String returns the Value of the Field with the given FieldID, FullFieldID, Name or DisplayName as
+// it is sent to Cherwell. It works for Fields of every Type.
+func (v *RecordValues) String(field string) (string, error) {
+	_, value, err := v.value(field)
+	return value, err
+}
+
+// Int returns the Value of the Number-Field with the given FieldID, FullFieldID, Name or DisplayName as Integer.
+// Empty Values return 0.
+func (v *RecordValues) Int(field string) (int64, error) {
+	value, err := v.typedValue(field, "Number")
+	if err != nil || value == "" {
+		return 0, err
+	}
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return i, nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f != math.Trunc(f) || math.Abs(f) > math.MaxInt64 {
+		return 0, fmt.Errorf("%w: Field %v invalid: %q is not an Integer", ErrValidation, field, value)
+	}
+	return int64(f), nil
+}
+
+// Decimal returns the Value of the Number-Field with the given FieldID, FullFieldID, Name or DisplayName as Decimal.
+// Empty Values return 0.
+func (v *RecordValues) Decimal(field string) (float64, error) {
+	value, err := v.typedValue(field, "Number")
+	if err != nil || value == "" {
+		return 0, err
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: Field %v invalid: %q is not a Number", ErrValidation, field, value)
+	}
+	return f, nil
+}
+
+// Bool returns the Value of the Logical-Field with the given FieldID, FullFieldID, Name or DisplayName.
+// Empty Values return false.
+func (v *RecordValues) Bool(field string) (bool, error) {
+	value, err := v.typedValue(field, "Logical")
+	if err != nil || value == "" {
+		return false, err
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%w: Field %v invalid: %q is not a Logical", ErrValidation, field, value)
+	}
+	return b, nil
+}
+
+// Time returns the Value of the DateTime-Field with the given FieldID, FullFieldID, Name or DisplayName.
+// Cherwell sends Times without Location, so they are returned in UTC. Empty Values return the zero Time.
+func (v *RecordValues) Time(field string) (time.Time, error) {
+	value, err := v.typedValue(field, "DateTime")
+	if err != nil || value == "" {
+		return time.Time{}, err
+	}
+	t, err := parseTime(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: Field %v invalid: %q is not a Date", ErrValidation, field, value)
+	}
+	return t, nil
+}
+
+// Date returns the Value of the DateTime-Field with the given FieldID, FullFieldID, Name or DisplayName
+// without its Time. Empty Values return the zero Time.
+func (v *RecordValues) Date(field string) (time.Time, error) {
+	t, err := v.Time(field)
+	if err != nil {
+		return t, err
+	}
+	return truncateDate(t), nil
+}
+
+// Set converts the Value by the Type of the Field with the given FieldID, FullFieldID, Name or DisplayName
+// to the Wire format of Cherwell and stores it in FieldValues, to be saved by SaveBusinessObjectRecord.
+// Number-Fields take Integers, Decimals and numeric Strings, Logical-Fields bools and Strings like "true",
+// DateTime-Fields time.Time and Strings in a Date format of Cherwell and Text-Fields Strings and fmt.Stringers.
+// nil clears the Field. Other Values and ReadOnly Fields return ErrValidation.
+func (v *RecordValues) Set(field string, value interface{}) error {
+	def, key, err := v.field(field)
+	if err != nil {
+		return err
+	}
+	if def.ReadOnly {
+		return fmt.Errorf("%w: Field %v invalid: readOnly", ErrValidation, field)
+	}
+	s, err := convertValue(def, value)
+	if err != nil {
+		return fmt.Errorf("%w: Field %v invalid: %v", ErrValidation, field, err)
+	}
+	if v.rec.FieldValues == nil {
+		v.rec.FieldValues = make(map[string]interface{})
+	}
+	v.rec.FieldValues[key] = s
+	return nil
+}
+
+// SetString sets the Value of the Field with the given FieldID, FullFieldID, Name or DisplayName like Set.
+func (v *RecordValues) SetString(field, value string) error {
+	return v.Set(field, value)
+}
+
+// SetInt sets the Value of the Number-Field with the given FieldID, FullFieldID, Name or DisplayName.
+func (v *RecordValues) SetInt(field string, value int64) error {
+	return v.Set(field, value)
+}
+
+// SetDecimal sets the Value of the Number-Field with the given FieldID, FullFieldID, Name or DisplayName.
+// It is formatted with the DecimalDigits of the Field.
+func (v *RecordValues) SetDecimal(field string, value float64) error {
+	return v.Set(field, value)
+}
+
+// SetBool sets the Value of the Logical-Field with the given FieldID, FullFieldID, Name or DisplayName.
+func (v *RecordValues) SetBool(field string, value bool) error {
+	return v.Set(field, value)
+}
+
+// SetTime sets the Value of the DateTime-Field with the given FieldID, FullFieldID, Name or DisplayName.
+// It is formatted with the Date, the Time or both, depending on HasDate and HasTime of the Field.
+func (v *RecordValues) SetTime(field string, value time.Time) error {
+	return v.Set(field, value)
+}
+
+// SetDate sets the Value of the DateTime-Field with the given FieldID, FullFieldID, Name or DisplayName
+// to the Date of the given Time at midnight.
+func (v *RecordValues) SetDate(field string, value time.Time) error {
+	return v.Set(field, truncateDate(value))
+}
+
+// field returns the FieldDefinition of the Field and its Key in FieldValues. Without BusinessObjectSchema
+// the FieldDefinition is derived from the Field of the BusinessObjectRecord and has no Type
+func (v *RecordValues) field(id string) (*FieldDefinition, string, error) {
+	if v == nil || v.rec == nil {
+		return nil, "", fmt.Errorf("%w: BusinessObjectRecord", ErrNilReceiver)
+	}
+	if v.sch == nil {
+		f, err := v.rec.Field(id)
+		if err != nil {
+			if _, ok := v.rec.FieldValues[id]; ok {
+				return &FieldDefinition{DisplayName: id}, id, nil
+			}
+			return nil, "", err
+		}
+		return &FieldDefinition{FieldID: f.FieldID, Name: f.Name, DisplayName: f.DisplayName}, f.DisplayName, nil
+	}
+	def, err := v.sch.FieldDefinition(id)
+	if err != nil {
+		return nil, "", err
+	}
+	if f, err := v.rec.Field(def.FieldID); err == nil {
+		return def, f.DisplayName, nil
+	}
+	return def, def.DisplayName, nil
+}
+
+// value returns the FieldDefinition of the Field and its Value in the Wire format of Cherwell.
+// Values of FieldValues take precedence over the Values of Fields
+func (v *RecordValues) value(id string) (*FieldDefinition, string, error) {
+	def, key, err := v.field(id)
+	if err != nil {
+		return nil, "", err
+	}
+	if value, ok := v.rec.FieldValues[key]; ok {
+		return def, formatValue(def, value), nil
+	}
+	if f, err := v.rec.Field(key); err == nil {
+		return def, f.Value, nil
+	}
+	return def, "", nil
+}
+
+// typedValue returns the Value of the Field or an Error if the Field is not of the given Type.
+// Fields without Type are not checked
+func (v *RecordValues) typedValue(id, typ string) (string, error) {
+	def, value, err := v.value(id)
+	if err != nil {
+		return "", err
+	}
+	ok := def.Type == "" || def.Type == typ
+	if typ == "DateTime" {
+		ok = ok || isDateTime(def)
+	}
+	if !ok {
+		return "", fmt.Errorf("%w: Field %v is %v, not %v", ErrValidation, id, def.Type, typ)
+	}
+	return value, nil
+}
+
+// convertValue converts a Value by the Type of the Field to the Wire format of Cherwell
+func convertValue(def *FieldDefinition, value interface{}) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+	switch {
+	case def.Type == "Number":
+		switch val := value.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			return fmt.Sprint(val), nil
+		case float32, float64:
+			return formatValue(def, val), nil
+		case string:
+			if val == "" {
+				return "", nil
+			}
+			f, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return "", fmt.Errorf("%q is not a Number", val)
+			}
+			return formatValue(def, f), nil
+		}
+		return "", fmt.Errorf("%T is not a Number", value)
+	case isDateTime(def):
+		switch val := value.(type) {
+		case time.Time:
+			return formatValue(def, val), nil
+		case string:
+			if val == "" {
+				return "", nil
+			}
+			t, err := parseTime(val)
+			if err != nil {
+				return "", fmt.Errorf("%q is not a Date", val)
+			}
+			return formatValue(def, t), nil
+		}
+		return "", fmt.Errorf("%T is not a Date", value)
+	case def.Type == "Logical":
+		switch val := value.(type) {
+		case bool:
+			return formatValue(def, val), nil
+		case string:
+			if val == "" {
+				return "", nil
+			}
+			b, err := strconv.ParseBool(val)
+			if err != nil {
+				return "", fmt.Errorf("%q is not a Logical", val)
+			}
+			return formatValue(def, b), nil
+		}
+		return "", fmt.Errorf("%T is not a Logical", value)
+	case def.Type == "Text":
+		switch value.(type) {
+		case string, fmt.Stringer:
+			return formatValue(def, value), nil
+		}
+		return "", fmt.Errorf("%T is not a Text", value)
+	}
+	return formatValue(def, value), nil
+}
+
+// formatsByDefinition reports whether formatValue formats the Value by the FieldDefinition
+func formatsByDefinition(v interface{}) bool {
+	switch v.(type) {
+	case time.Time, float32, float64:
+		return true
+	}
+	return false
+}
+
+// sameValue reports whether a Value of FieldValues equals the Value of a Field, comparing
+// Times, Logicals and Numbers by their Value instead of their Format
+func sameValue(value string, v interface{}) bool {
+	switch val := v.(type) {
+	case nil:
+		return value == ""
+	case string:
+		return value == val
+	case time.Time:
+		t, err := parseTime(value)
+		return err == nil && t.Format("2006-01-02T15:04:05") == val.Format("2006-01-02T15:04:05")
+	case bool:
+		b, err := strconv.ParseBool(value)
+		return err == nil && b == val
+	}
+	s := formatValue(nil, v)
+	x, errX := strconv.ParseFloat(value, 64)
+	y, errY := strconv.ParseFloat(s, 64)
+	if errX == nil && errY == nil {
+		return x == y
+	}
+	return value == s
+}
+
+// truncateDate returns the Date of a Time at midnight
+func truncateDate(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,172 @@
+package gocherwell_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/itsscb/gocherwell"
+	"github.com/itsscb/gocherwell/cherwelltest"
+)
+
+// newAssetServer returns a Server with the BusinessObject Asset, whose Fields have German DisplayNames
+// and one of each Type, and a single Record.
+func newAssetServer() *cherwelltest.Server {
+	return cherwelltest.NewServer(cherwelltest.BusinessObject{
+		BusObID:     "BOAsset",
+		Name:        "Asset",
+		DisplayName: "Anlage",
+		Fields: []cherwelltest.Field{
+			{Name: "AssetName", DisplayName: "Name"},
+			{Name: "Count", DisplayName: "Anzahl", Type: "Number"},
+			{Name: "Price", DisplayName: "Preis", Type: "Number", DecimalDigits: 2},
+			{Name: "Active", DisplayName: "Aktiv", Type: "Logical"},
+			{Name: "Bought", DisplayName: "Gekauft", Type: "DateTime", HasDate: true},
+			{Name: "Updated", DisplayName: "Geändert", Type: "DateTime", HasDate: true, HasTime: true},
+			{Name: "Serial", DisplayName: "Seriennummer", ReadOnly: true},
+		},
+		Records: []map[string]string{{
+			"AssetName": "A1",
+			"Count":     "3",
+			"Price":     "12.50",
+			"Active":    "True",
+			"Bought":    "2026-03-01",
+			"Updated":   "2026-03-01T08:30:00",
+			"Serial":    "S1",
+		}},
+	})
+}
+
+// assetValues returns the Record of the Server and its RecordValues
+func assetValues(t *testing.T, ctx context.Context, cl *gocherwell.Client) (*gocherwell.BusinessObjectRecord, *gocherwell.RecordValues) {
+	t.Helper()
+	bo, err := cl.ResolveBusinessObject(ctx, "Asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sch, err := bo.GetBusinessObjectSchema(ctx, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec, err := bo.NewQuery().First(ctx, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rec, rec.Values(sch)
+}
+
+func TestRecordValuesGet(t *testing.T) {
+	tests := []struct {
+		name string
+		get  func(v *gocherwell.RecordValues) (interface{}, error)
+		want interface{}
+		err  error
+	}{
+		{"string by display name", func(v *gocherwell.RecordValues) (interface{}, error) { return v.String("Name") }, "A1", nil},
+		{"string by name", func(v *gocherwell.RecordValues) (interface{}, error) { return v.String("AssetName") }, "A1", nil},
+		{"string by field id", func(v *gocherwell.RecordValues) (interface{}, error) { return v.String("FICount") }, "3", nil},
+		{"string by full field id", func(v *gocherwell.RecordValues) (interface{}, error) { return v.String("BO:BOAsset,FI:FIPrice") }, "12.50", nil},
+		{"int", func(v *gocherwell.RecordValues) (interface{}, error) { return v.Int("Anzahl") }, int64(3), nil},
+		{"decimal", func(v *gocherwell.RecordValues) (interface{}, error) { return v.Decimal("Price") }, 12.5, nil},
+		{"bool", func(v *gocherwell.RecordValues) (interface{}, error) { return v.Bool("Active") }, true, nil},
+		{"time", func(v *gocherwell.RecordValues) (interface{}, error) { return v.Time("Geändert") }, time.Date(2026, 3, 1, 8, 30, 0, 0, time.UTC), nil},
+		{"date", func(v *gocherwell.RecordValues) (interface{}, error) { return v.Date("Updated") }, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), nil},
+		{"date only", func(v *gocherwell.RecordValues) (interface{}, error) { return v.Time("Bought") }, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), nil},
+		{"int of decimal", func(v *gocherwell.RecordValues) (interface{}, error) { return v.Int("Price") }, int64(0), gocherwell.ErrValidation},
+		{"bool of number", func(v *gocherwell.RecordValues) (interface{}, error) { return v.Bool("Count") }, false, gocherwell.ErrValidation},
+		{"time of text", func(v *gocherwell.RecordValues) (interface{}, error) { return v.Time("AssetName") }, time.Time{}, gocherwell.ErrValidation},
+		{"unknown field", func(v *gocherwell.RecordValues) (interface{}, error) { return v.String("Missing") }, "", gocherwell.ErrNotFound},
+	}
+	srv := newAssetServer()
+	defer srv.Close()
+	ctx := context.Background()
+	_, values := assetValues(t, ctx, srv.NewClient())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.get(values)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("error = %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordValuesSet(t *testing.T) {
+	updated := time.Date(2026, 4, 2, 9, 15, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		set   func(v *gocherwell.RecordValues) error
+		field string
+		want  string
+		err   error
+	}{
+		{"string", func(v *gocherwell.RecordValues) error { return v.SetString("Name", "A2") }, "AssetName", "A2", nil},
+		{"int", func(v *gocherwell.RecordValues) error { return v.SetInt("Count", 7) }, "Count", "7", nil},
+		{"decimal", func(v *gocherwell.RecordValues) error { return v.SetDecimal("Preis", 9.5) }, "Price", "9.50", nil},
+		{"numeric string", func(v *gocherwell.RecordValues) error { return v.Set("Count", "4.0") }, "Count", "4", nil},
+		{"bool", func(v *gocherwell.RecordValues) error { return v.SetBool("Aktiv", false) }, "Active", "False", nil},
+		{"time", func(v *gocherwell.RecordValues) error { return v.SetTime("Geändert", updated) }, "Updated", "2026-04-02T09:15:00", nil},
+		{"date", func(v *gocherwell.RecordValues) error { return v.SetDate("Updated", updated) }, "Updated", "2026-04-02T00:00:00", nil},
+		{"time of date field", func(v *gocherwell.RecordValues) error { return v.SetTime("Bought", updated) }, "Bought", "2026-04-02", nil},
+		{"clear", func(v *gocherwell.RecordValues) error { return v.Set("Count", nil) }, "Count", "", nil},
+		{"unchanged", func(v *gocherwell.RecordValues) error { return v.SetDecimal("Price", 12.5) }, "Price", "12.50", nil},
+		{"invalid logical", func(v *gocherwell.RecordValues) error { return v.Set("Active", "yes") }, "Active", "True", gocherwell.ErrValidation},
+		{"invalid number", func(v *gocherwell.RecordValues) error { return v.Set("Count", struct{}{}) }, "Count", "3", gocherwell.ErrValidation},
+		{"invalid date", func(v *gocherwell.RecordValues) error { return v.Set("Bought", "yesterday") }, "Bought", "2026-03-01", gocherwell.ErrValidation},
+		{"read only", func(v *gocherwell.RecordValues) error { return v.SetString("Seriennummer", "S2") }, "Serial", "S1", gocherwell.ErrValidation},
+		{"unknown field", func(v *gocherwell.RecordValues) error { return v.SetString("Missing", "x") }, "AssetName", "A1", gocherwell.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newAssetServer()
+			defer srv.Close()
+			ctx := context.Background()
+			cl := srv.NewClient()
+			rec, values := assetValues(t, ctx, cl)
+			if err := tt.set(values); !errors.Is(err, tt.err) {
+				t.Fatalf("error = %v, want %v", err, tt.err)
+			}
+			if _, err := rec.SaveBusinessObjectRecord(ctx, cl); err != nil {
+				t.Fatalf("SaveBusinessObjectRecord() error = %v", err)
+			}
+			if got := srv.Records("Asset")[0][tt.field]; got != tt.want {
+				t.Errorf("saved %v = %q, want %q", tt.field, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveFieldValuesByDefinition(t *testing.T) {
+	tests := []struct {
+		name  string
+		key   string
+		value interface{}
+		field string
+		want  string
+	}{
+		{"date only", "Gekauft", time.Date(2026, 4, 2, 9, 15, 0, 0, time.UTC), "Bought", "2026-04-02"},
+		{"date and time", "Updated", time.Date(2026, 4, 2, 9, 15, 0, 0, time.UTC), "Updated", "2026-04-02T09:15:00"},
+		{"decimal digits", "Preis", 9.5, "Price", "9.50"},
+		{"unchanged decimal", "Price", 12.5, "Price", "12.50"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newAssetServer()
+			defer srv.Close()
+			ctx := context.Background()
+			cl := srv.NewClient()
+			rec, _ := assetValues(t, ctx, cl)
+			rec.FieldValues[tt.key] = tt.value
+			if _, err := rec.SaveBusinessObjectRecord(ctx, cl); err != nil {
+				t.Fatalf("SaveBusinessObjectRecord() error = %v", err)
+			}
+			if got := srv.Records("Asset")[0][tt.field]; got != tt.want {
+				t.Errorf("saved %v = %q, want %q", tt.field, got, tt.want)
+			}
+		})
+	}
+}